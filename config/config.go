@@ -39,8 +39,9 @@ type CommonServiceConfig struct {
 	} `mapstructure:",squash"`
 
 	Log struct {
-		Level  string `mapstructure:"log-level" default:"info" description:"Set the log level"`
-		NoJson bool   `mapstructure:"no-json" default:"false" description:"Disable JSON logging"`
+		Level           string `mapstructure:"log-level" default:"info" description:"Set the log level"`
+		NoJson          bool   `mapstructure:"no-json" default:"false" description:"Disable JSON logging"`
+		ComponentLevels string `mapstructure:"log-component-levels" description:"Set the log level per component, e.g. lifecycle=debug,graphql=warn"`
 	} `mapstructure:",squash"`
 
 	ShutdownTimeout time.Duration `mapstructure:"shutdown-timeout" default:"1m" description:"Set the shutdown timeout as duration in seconds, e.g. 30s, 1m, 2h"`
@@ -60,7 +61,15 @@ type CommonServiceConfig struct {
 	} `mapstructure:",squash"`
 
 	Sentry struct {
-		Dsn string `mapstructure:"sentry-dsn" description:"Set the Sentry DSN for error reporting"`
+		Dsn              string  `mapstructure:"sentry-dsn" description:"Set the Sentry DSN for error reporting"`
+		SampleRate       float64 `mapstructure:"sentry-sample-rate" default:"1.0" description:"Set the share of error events sent to Sentry, greater than 0.0 and at most 1.0"`
+		TracesSampleRate float64 `mapstructure:"sentry-traces-sample-rate" default:"1.0" description:"Set the share of transactions sent to Sentry between 0.0 and 1.0"`
+		AttachStacktrace bool    `mapstructure:"sentry-attach-stacktrace" default:"true" description:"Attach stack traces to Sentry messages"`
+		Debug            bool    `mapstructure:"sentry-debug" default:"false" description:"Enable debug output of the Sentry client"`
+		ServerName       string  `mapstructure:"sentry-server-name" description:"Set the server name reported to Sentry"`
+		IgnoreErrors     string  `mapstructure:"sentry-ignore-errors" description:"Set a comma separated list of regular expressions matching error messages which are not sent to Sentry"`
+		MaxBreadcrumbs   int     `mapstructure:"sentry-max-breadcrumbs" default:"100" description:"Set the maximum number of Sentry breadcrumbs"`
+		HTTPProxy        string  `mapstructure:"sentry-http-proxy" description:"Set the proxy used to send events to Sentry"`
 	} `mapstructure:",squash"`
 }
 
@@ -166,6 +175,16 @@ func traverseStruct(value reflect.Value, flagSet *pflag.FlagSet, prefix string)
 				defaultBoolValue = b
 			}
 			flagSet.Bool(prefix+tag, defaultBoolValue, description)
+		case reflect.Float64:
+			var defaultFloatValue float64
+			if defaultStrValue != "" {
+				f, err := strconv.ParseFloat(defaultStrValue, 64)
+				if err != nil {
+					return err
+				}
+				defaultFloatValue = f
+			}
+			flagSet.Float64(prefix+tag, defaultFloatValue, description)
 		default:
 			return fmt.Errorf("unsupported field type %s for field %s", fieldValue.Kind(), field.Name)
 		}
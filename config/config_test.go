@@ -25,10 +25,11 @@ func TestBindConfigToFlags(t *testing.T) {
 
 	type test struct {
 		config.CommonServiceConfig
-		CustomFlag          string `mapstructure:"custom-flag" default:"abc" description:"This is a custom flag"`
-		CustomFlagInt       int    `mapstructure:"custom-flag-int" default:"123" description:"This is a custom flag with int value"`
-		CustomFlagBool      bool   `mapstructure:"custom-flag-bool" default:"true" description:"This is a custom flag with bool value"`
-		CustomFlagNoDefault string `mapstructure:"custom-flag-no-default" `
+		CustomFlag          string  `mapstructure:"custom-flag" default:"abc" description:"This is a custom flag"`
+		CustomFlagInt       int     `mapstructure:"custom-flag-int" default:"123" description:"This is a custom flag with int value"`
+		CustomFlagBool      bool    `mapstructure:"custom-flag-bool" default:"true" description:"This is a custom flag with bool value"`
+		CustomFlagFloat     float64 `mapstructure:"custom-flag-float" default:"0.5" description:"This is a custom flag with float value"`
+		CustomFlagNoDefault string  `mapstructure:"custom-flag-no-default" `
 		CustomFlagStruct    struct {
 			CustomFlagDuration time.Duration `mapstructure:"custom-flag-duration" default:"1m" description:"This is a custom flag with duration value"`
 			SubCustomFlag      string        `mapstructure:"sub-custom-flag" default:"subabc" description:"This is a sub custom flag"`
@@ -70,6 +71,11 @@ func TestBindConfigToFlags(t *testing.T) {
 	assert.Equal(t, "This is a custom flag with bool value", boolFlag.Usage)
 	assert.Equal(t, "true", boolFlag.DefValue)
 
+	floatFlag := cmd.Flags().Lookup("custom-flag-float")
+	assert.NotNil(t, floatFlag)
+	assert.Equal(t, "This is a custom flag with float value", floatFlag.Usage)
+	assert.Equal(t, "0.5", floatFlag.DefValue)
+
 	durationFlag := cmd.Flags().Lookup("custom-flag-duration")
 	assert.NotNil(t, durationFlag)
 	assert.Equal(t, "This is a custom flag with duration value", durationFlag.Usage)
@@ -118,6 +124,20 @@ func TestBindConfigToFlagsWrongTypeBool(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBindConfigToFlagsWrongTypeFloat(t *testing.T) {
+	type test struct {
+		CustomFlagFloat float64 `mapstructure:"custom-flag" default:"abc"`
+	}
+
+	testStruct := test{}
+
+	v := viper.New()
+
+	cmd := &cobra.Command{}
+	err := config.BindConfigToFlags(v, cmd, &testStruct) // assuming this binds flags
+	assert.Error(t, err)
+}
+
 func TestBindConfigToFlagsEmptyDefaultBool(t *testing.T) {
 	type test struct {
 		CustomFlagInt bool `mapstructure:"custom-flag" default:""`
@@ -168,3 +188,39 @@ func TestNewDefaultConfig(t *testing.T) {
 	err = v.Unmarshal(&config.CommonServiceConfig{})
 	assert.NoError(t, err)
 }
+
+func TestNewDefaultConfigSentryDefaults(t *testing.T) {
+	cmd := &cobra.Command{}
+	v, _, err := config.NewDefaultConfig(cmd)
+	assert.NoError(t, err)
+
+	cfg := config.CommonServiceConfig{}
+	err = v.Unmarshal(&cfg)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1.0, cfg.Sentry.SampleRate)
+	assert.Equal(t, 1.0, cfg.Sentry.TracesSampleRate)
+	assert.True(t, cfg.Sentry.AttachStacktrace)
+	assert.Equal(t, 100, cfg.Sentry.MaxBreadcrumbs)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("sentry-ignore-errors"))
+
+	err = cmd.PersistentFlags().Set("sentry-traces-sample-rate", "0.25")
+	assert.NoError(t, err)
+	err = v.Unmarshal(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, cfg.Sentry.TracesSampleRate)
+}
+
+func TestNewDefaultConfigLogComponentLevels(t *testing.T) {
+	cmd := &cobra.Command{}
+	v, _, err := config.NewDefaultConfig(cmd)
+	assert.NoError(t, err)
+
+	err = cmd.PersistentFlags().Set("log-component-levels", "lifecycle=debug,graphql=warn")
+	assert.NoError(t, err)
+
+	cfg := config.CommonServiceConfig{}
+	err = v.Unmarshal(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "lifecycle=debug,graphql=warn", cfg.Log.ComponentLevels)
+}
@@ -0,0 +1,62 @@
+// Package sentryconfig starts Sentry from the settings of the CommonServiceConfig. It is kept apart from the sentry
+// package, so services using Sentry without the config do not depend on cobra and viper.
+package sentryconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openmfp/golang-commons/config"
+	"github.com/openmfp/golang-commons/sentry"
+)
+
+// ClientOptions reads the client options from the Sentry settings of the CommonServiceConfig.
+// Sample rates have to be between 0.0 and 1.0, a sample rate of 0.0 is rejected as Sentry would send all events.
+// Configs built in code have to set the sample rate, the flags default to 1.0.
+func ClientOptions(cfg *config.CommonServiceConfig) (sentry.ClientOptions, error) {
+	clientOptions := sentry.ClientOptions{
+		SampleRate:       cfg.Sentry.SampleRate,
+		TracesSampleRate: cfg.Sentry.TracesSampleRate,
+		AttachStacktrace: cfg.Sentry.AttachStacktrace,
+		Debug:            cfg.Sentry.Debug,
+		ServerName:       cfg.Sentry.ServerName,
+		MaxBreadcrumbs:   cfg.Sentry.MaxBreadcrumbs,
+		HTTPProxy:        cfg.Sentry.HTTPProxy,
+	}
+	if err := validateSampleRate(clientOptions.SampleRate); err != nil {
+		return clientOptions, fmt.Errorf("invalid sentry sample rate: %w", err)
+	}
+	// the Sentry client replaces a sample rate of 0.0 with 1.0
+	if clientOptions.SampleRate == 0 {
+		return clientOptions, errors.New("invalid sentry sample rate: 0.0 sends all events, use an empty DSN to disable Sentry")
+	}
+	if err := validateSampleRate(clientOptions.TracesSampleRate); err != nil {
+		return clientOptions, fmt.Errorf("invalid sentry traces sample rate: %w", err)
+	}
+	for _, pattern := range strings.Split(cfg.Sentry.IgnoreErrors, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			clientOptions.IgnoreErrors = append(clientOptions.IgnoreErrors, pattern)
+		}
+	}
+	return clientOptions, nil
+}
+
+func validateSampleRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("%v is not between 0.0 and 1.0", rate)
+	}
+	return nil
+}
+
+// Start starts Sentry with the DSN, environment, region, image and client options of the CommonServiceConfig.
+// Options passed explicitly take precedence over the config.
+func Start(ctx context.Context, cfg *config.CommonServiceConfig, opts ...sentry.Option) error {
+	clientOptions, err := ClientOptions(cfg)
+	if err != nil {
+		return err
+	}
+	opts = append([]sentry.Option{sentry.WithClientOptions(clientOptions)}, opts...)
+	return sentry.Start(ctx, cfg.Sentry.Dsn, cfg.Environment, cfg.Region, cfg.Image.Name, cfg.Image.Tag, opts...)
+}
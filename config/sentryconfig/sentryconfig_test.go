@@ -0,0 +1,100 @@
+package sentryconfig
+
+import (
+	"context"
+	"testing"
+
+	sentrygo "github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openmfp/golang-commons/config"
+	"github.com/openmfp/golang-commons/sentry"
+)
+
+func TestClientOptions(t *testing.T) {
+	t.Run("Read options", func(t *testing.T) {
+		cfg := &config.CommonServiceConfig{}
+		cfg.Sentry.SampleRate = 0.5
+		cfg.Sentry.TracesSampleRate = 0.1
+		cfg.Sentry.AttachStacktrace = true
+		cfg.Sentry.Debug = true
+		cfg.Sentry.ServerName = "server"
+		cfg.Sentry.IgnoreErrors = "context canceled, ^not found$,"
+		cfg.Sentry.MaxBreadcrumbs = 50
+		cfg.Sentry.HTTPProxy = "http://proxy:8080"
+
+		clientOptions, err := ClientOptions(cfg)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sentry.ClientOptions{
+			SampleRate:       0.5,
+			TracesSampleRate: 0.1,
+			AttachStacktrace: true,
+			Debug:            true,
+			ServerName:       "server",
+			IgnoreErrors:     []string{"context canceled", "^not found$"},
+			MaxBreadcrumbs:   50,
+			HTTPProxy:        "http://proxy:8080",
+		}, clientOptions)
+	})
+
+	t.Run("Take the options of configs built in code as is", func(t *testing.T) {
+		cfg := &config.CommonServiceConfig{}
+		cfg.Sentry.SampleRate = 1.0
+
+		clientOptions, err := ClientOptions(cfg)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sentry.ClientOptions{SampleRate: 1.0}, clientOptions)
+	})
+
+	t.Run("Reject invalid sample rates", func(t *testing.T) {
+		cfg := &config.CommonServiceConfig{}
+		cfg.Sentry.SampleRate = -0.5
+		_, err := ClientOptions(cfg)
+		assert.Error(t, err)
+
+		cfg = &config.CommonServiceConfig{}
+		cfg.Sentry.SampleRate = 1.0
+		cfg.Sentry.TracesSampleRate = 1.5
+		_, err = ClientOptions(cfg)
+		assert.Error(t, err)
+	})
+
+	t.Run("Reject a sample rate of zero", func(t *testing.T) {
+		_, err := ClientOptions(&config.CommonServiceConfig{})
+		assert.ErrorContains(t, err, "use an empty DSN to disable Sentry")
+
+		cfg := &config.CommonServiceConfig{}
+		cfg.Sentry.SampleRate = 1.0
+		clientOptions, err := ClientOptions(cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, clientOptions.TracesSampleRate)
+	})
+}
+
+func TestStart(t *testing.T) {
+	cfg := &config.CommonServiceConfig{Environment: "dev", Region: "eu"}
+	cfg.Image.Name = "image"
+	cfg.Image.Tag = "tag"
+	cfg.Sentry.SampleRate = 1.0
+	cfg.Sentry.TracesSampleRate = 0.25
+	cfg.Sentry.ServerName = "server"
+
+	err := Start(context.Background(), cfg)
+
+	require.NoError(t, err)
+	clientOptions := sentrygo.CurrentHub().Client().Options()
+	assert.Equal(t, "dev-eu", clientOptions.Environment)
+	assert.Equal(t, "image:tag", clientOptions.Release)
+	assert.Equal(t, 0.25, clientOptions.TracesSampleRate)
+	assert.Equal(t, "server", clientOptions.ServerName)
+}
+
+func TestStartWithInvalidConfig(t *testing.T) {
+	cfg := &config.CommonServiceConfig{}
+	cfg.Sentry.SampleRate = 2
+
+	assert.Error(t, Start(context.Background(), cfg))
+}
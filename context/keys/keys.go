@@ -6,9 +6,11 @@ type ContextKey string
 
 const (
 	RequestIdCtxKey     = ContextKey("request-id")
+	ReconcileIdCtxKey   = ContextKey("reconcile-id")
 	LoggerCtxKey        = ContextKey("logger")
 	ConfigCtxKey        = ContextKey("config")
 	SentryTagsCtxKey    = ContextKey("sentryTags")
+	SentryExtrasCtxKey  = ContextKey("sentryExtras")
 	TechnicalUserCtxKey = ContextKey("technicalUser")
 	SpiffeCtxKey        = ContextKey(jwt.SpiffeCtxKey)
 	TenantIdCtxKey      = ContextKey(jwt.TenantIdCtxKey)
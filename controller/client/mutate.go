@@ -0,0 +1,301 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gomodules.xyz/jsonpatch/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	controllererrors "github.com/openmfp/golang-commons/controller/errors"
+)
+
+const (
+	defaultFieldOwner = "openmfp"
+
+	resourceVersionPath = "/metadata/resourceVersion"
+)
+
+// MutateFunc adjusts the object before it is written. Return an AbortError to stop without further retries.
+type MutateFunc func(client.Object) error
+
+// AbortError is returned by a MutateFunc to stop the mutation. It is returned as is and never retried.
+type AbortError struct {
+	Err error
+}
+
+// NewAbortError wraps an error into an AbortError
+func NewAbortError(err error) *AbortError {
+	return &AbortError{Err: err}
+}
+
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("mutation aborted: %v", e.Err)
+}
+
+func (e *AbortError) Unwrap() error {
+	return e.Err
+}
+
+// IsAbortError checks if the error or one of its wrapped errors is an AbortError
+func IsAbortError(err error) bool {
+	var abortErr *AbortError
+	return errors.As(err, &abortErr)
+}
+
+type options struct {
+	backoff        wait.Backoff
+	status         bool
+	optimisticLock bool
+	fieldOwner     string
+	forceOwnership bool
+}
+
+// Option configures the retrying mutation helpers
+type Option func(*options)
+
+// WithBackoff overwrites the default backoff. Steps defines the maximum number of attempts.
+func WithBackoff(backoff wait.Backoff) Option {
+	return func(o *options) {
+		o.backoff = backoff
+	}
+}
+
+// WithStatusSubresource writes the status subresource instead of the object.
+// RetryCreateOrPatch rejects it, as it patches the status together with the object.
+func WithStatusSubresource() Option {
+	return func(o *options) {
+		o.status = true
+	}
+}
+
+// WithOptimisticLock adds the resource version to merge patches so concurrent changes result in a retried conflict.
+// It is only supported by RetryMergePatch and rejected by RetryCreateOrPatch.
+func WithOptimisticLock() Option {
+	return func(o *options) {
+		o.optimisticLock = true
+	}
+}
+
+// WithFieldOwner sets the field manager used by RetryApply and RetryCreateOrPatch
+func WithFieldOwner(fieldOwner string) Option {
+	return func(o *options) {
+		o.fieldOwner = fieldOwner
+	}
+}
+
+// WithForceOwnership forces conflicting fields to be owned by the field owner during server-side apply.
+// It is only supported by RetryApply and rejected by RetryCreateOrPatch.
+func WithForceOwnership() Option {
+	return func(o *options) {
+		o.forceOwnership = true
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		backoff: defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// patchOption is fulfilled by patch options which are valid for objects and subresources
+type patchOption interface {
+	client.PatchOption
+	client.SubResourcePatchOption
+}
+
+func (o *options) patch(ctx context.Context, cl client.Client, obj client.Object, patch client.Patch, opts ...patchOption) error {
+	if o.status {
+		subResourcePatchOpts := make([]client.SubResourcePatchOption, 0, len(opts))
+		for _, opt := range opts {
+			subResourcePatchOpts = append(subResourcePatchOpts, opt)
+		}
+		return cl.Status().Patch(ctx, obj, patch, subResourcePatchOpts...)
+	}
+	patchOpts := make([]client.PatchOption, 0, len(opts))
+	for _, opt := range opts {
+		patchOpts = append(patchOpts, opt)
+	}
+	return cl.Patch(ctx, obj, patch, patchOpts...)
+}
+
+// RetryMergePatch reads the object, applies the mutate function and writes the changes as a merge patch
+func RetryMergePatch(ctx context.Context, cl client.Client, obj client.Object, mutate MutateFunc, opts ...Option) error {
+	o := newOptions(opts)
+	return retryOnRetriable(ctx, o.backoff, func() error {
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		original := obj.DeepCopyObject().(client.Object)
+		if err := callMutate(mutate, obj); err != nil {
+			return err
+		}
+
+		var patch client.Patch
+		if o.optimisticLock {
+			patch = client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+		} else {
+			patch = client.MergeFrom(original)
+		}
+		return o.patch(ctx, cl, obj, patch)
+	})
+}
+
+// RetryJSONPatch reads the object, applies the mutate function and writes the changes as a JSON patch.
+// The patch tests the resource version, so concurrent changes result in a retried conflict.
+func RetryJSONPatch(ctx context.Context, cl client.Client, obj client.Object, mutate MutateFunc, opts ...Option) error {
+	o := newOptions(opts)
+	return retryOnRetriable(ctx, o.backoff, func() error {
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		resourceVersion := obj.GetResourceVersion()
+		original, err := json.Marshal(obj)
+		if err != nil {
+			return NewAbortError(err)
+		}
+		if err := callMutate(mutate, obj); err != nil {
+			return err
+		}
+		modified, err := json.Marshal(obj)
+		if err != nil {
+			return NewAbortError(err)
+		}
+
+		operations, err := jsonpatch.CreatePatch(original, modified)
+		if err != nil {
+			return NewAbortError(err)
+		}
+		if len(operations) == 0 {
+			return nil
+		}
+		// list operations use indexes, the test operation prevents changing the wrong elements after concurrent changes
+		if resourceVersion != "" {
+			operations = append([]jsonpatch.Operation{jsonpatch.NewOperation("test", resourceVersionPath, resourceVersion)}, operations...)
+		}
+		data, err := json.Marshal(operations)
+		if err != nil {
+			return NewAbortError(err)
+		}
+		err = o.patch(ctx, cl, obj, client.RawPatch(types.JSONPatchType, data))
+		if isResourceVersionTestFailure(err) {
+			return kerrors.NewConflict(schema.GroupResource{}, obj.GetName(), err)
+		}
+		return err
+	})
+}
+
+// isResourceVersionTestFailure checks if the test operation on the resource version failed. The API server reports it as
+// invalid patch, so the error message is the only indication.
+func isResourceVersionTestFailure(err error) bool {
+	return err != nil && strings.Contains(err.Error(), fmt.Sprintf("testing value %s failed", resourceVersionPath))
+}
+
+// RetryApply applies the mutate function and writes the object using server-side apply.
+// The object has to contain the desired state including its GroupVersionKind, it is not read before.
+// Conflicts with other field managers are only retried with WithForceOwnership.
+func RetryApply(ctx context.Context, cl client.Client, obj client.Object, mutate MutateFunc, opts ...Option) error {
+	o := newOptions(opts)
+	fieldOwner := o.fieldOwner
+	if fieldOwner == "" {
+		fieldOwner = defaultFieldOwner
+	}
+	patchOpts := []patchOption{client.FieldOwner(fieldOwner)}
+	if o.forceOwnership {
+		patchOpts = append(patchOpts, client.ForceOwnership)
+	}
+	// conflicts with other field managers are only resolved by forcing the ownership
+	isRetriable := func(err error) (bool, ctrl.Result) {
+		if kerrors.IsConflict(err) && !o.forceOwnership {
+			return false, ctrl.Result{}
+		}
+		return controllererrors.IsRetriable(err)
+	}
+	return retryWith(ctx, o.backoff, isRetriable, func() error {
+		if err := callMutate(mutate, obj); err != nil {
+			return err
+		}
+		return o.patch(ctx, cl, obj, client.Apply, patchOpts...)
+	})
+}
+
+// RetryCreateOrPatch creates the object if it does not exist, otherwise it applies the mutate function and writes the
+// changes as merge patch. The status is patched as well in case it was changed by the mutate function.
+// WithStatusSubresource, WithOptimisticLock and WithForceOwnership are not supported and result in an error.
+func RetryCreateOrPatch(ctx context.Context, cl client.Client, obj client.Object, mutate MutateFunc, opts ...Option) (controllerutil.OperationResult, error) {
+	o := newOptions(opts)
+	if o.status || o.optimisticLock || o.forceOwnership {
+		return controllerutil.OperationResultNone, errors.New("RetryCreateOrPatch does not support the status subresource, optimistic lock and force ownership options")
+	}
+	if o.fieldOwner != "" {
+		cl = client.WithFieldOwner(cl, o.fieldOwner)
+	}
+	result := controllerutil.OperationResultNone
+	err := retryOnRetriable(ctx, o.backoff, func() error {
+		var err error
+		result, err = controllerutil.CreateOrPatch(ctx, cl, obj, func() error {
+			return callMutate(mutate, obj)
+		})
+		return err
+	})
+	return result, err
+}
+
+func callMutate(mutate MutateFunc, obj client.Object) error {
+	if mutate == nil {
+		return nil
+	}
+	return mutate(obj)
+}
+
+// retryOnRetriable executes the operation until it succeeds, the error is not retriable, the backoff is exhausted or the
+// context is done. A delay suggested by the API server takes precedence over a shorter backoff delay.
+func retryOnRetriable(ctx context.Context, backoff wait.Backoff, operation func() error) error {
+	return retryWith(ctx, backoff, controllererrors.IsRetriable, operation)
+}
+
+// retryWith works like retryOnRetriable but decides with the given function which errors are retried
+func retryWith(ctx context.Context, backoff wait.Backoff, isRetriable func(error) (bool, ctrl.Result), operation func() error) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := operation()
+		if err == nil || IsAbortError(err) {
+			return err
+		}
+
+		retriable, result := isRetriable(err)
+		if !retriable || backoff.Steps <= 1 {
+			return err
+		}
+
+		delay := backoff.Step()
+		if result.RequeueAfter > delay {
+			delay = result.RequeueAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openmfp/golang-commons/controller/testSupport"
+)
+
+var testBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+func newTestObject() *testSupport.TestApiObject {
+	return &testSupport.TestApiObject{
+		ObjectMeta: v1.ObjectMeta{Name: "test", Namespace: "test"},
+	}
+}
+
+func setLabel(obj client.Object) error {
+	obj.SetLabels(map[string]string{"key": "value"})
+	return nil
+}
+
+func conflictOnFirstPatch(calls *int) interceptor.Funcs {
+	return interceptor.Funcs{
+		Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			*calls++
+			if *calls == 1 {
+				return kerrors.NewConflict(schema.GroupResource{}, obj.GetName(), errors.New("conflict"))
+			}
+			return cl.Patch(ctx, obj, patch, opts...)
+		},
+	}
+}
+
+func TestRetryMergePatch(t *testing.T) {
+	t.Run("Patch object", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, newTestObject())
+		o := newTestObject()
+
+		// Act
+		err := RetryMergePatch(context.Background(), c, o, setLabel, WithOptimisticLock())
+
+		// Assert
+		assert.NoError(t, err)
+		stored := newTestObject()
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(stored), stored))
+		assert.Equal(t, "value", stored.GetLabels()["key"])
+	})
+
+	t.Run("Patch status subresource", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, newTestObject())
+		o := newTestObject()
+
+		// Act
+		err := RetryMergePatch(context.Background(), c, o, func(obj client.Object) error {
+			obj.(*testSupport.TestApiObject).Status.Some = "status"
+			return nil
+		}, WithStatusSubresource())
+
+		// Assert
+		assert.NoError(t, err)
+		stored := newTestObject()
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(stored), stored))
+		assert.Equal(t, "status", stored.Status.Some)
+	})
+
+	t.Run("Retry on conflict", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		c := interceptor.NewClient(testSupport.CreateFakeClient(t, newTestObject()), conflictOnFirstPatch(&calls))
+
+		// Act
+		err := RetryMergePatch(context.Background(), c, newTestObject(), setLabel, WithBackoff(testBackoff))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Stop after backoff is exhausted", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		c := interceptor.NewClient(testSupport.CreateFakeClient(t, newTestObject()), interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				calls++
+				return kerrors.NewServiceUnavailable("unavailable")
+			},
+		})
+
+		// Act
+		err := RetryMergePatch(context.Background(), c, newTestObject(), setLabel, WithBackoff(testBackoff))
+
+		// Assert
+		assert.True(t, kerrors.IsServiceUnavailable(err))
+		assert.Equal(t, testBackoff.Steps, calls)
+	})
+
+	t.Run("Do not retry errors which are not retriable", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, newTestObject())
+		o := &testSupport.TestApiObject{ObjectMeta: v1.ObjectMeta{Name: "missing", Namespace: "test"}}
+
+		// Act
+		err := RetryMergePatch(context.Background(), c, o, setLabel, WithBackoff(testBackoff))
+
+		// Assert
+		assert.True(t, kerrors.IsNotFound(err))
+	})
+
+	t.Run("Abort from mutate function", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, newTestObject())
+		calls := 0
+		abortErr := errors.New("invalid state")
+
+		// Act
+		err := RetryMergePatch(context.Background(), c, newTestObject(), func(_ client.Object) error {
+			calls++
+			return NewAbortError(abortErr)
+		}, WithBackoff(testBackoff))
+
+		// Assert
+		assert.True(t, IsAbortError(err))
+		assert.ErrorIs(t, err, abortErr)
+		assert.Equal(t, "mutation aborted: invalid state", err.Error())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Stop on cancelled context", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, newTestObject())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		err := RetryMergePatch(ctx, c, newTestObject(), setLabel)
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Stop when context is cancelled while waiting", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		c := interceptor.NewClient(testSupport.CreateFakeClient(t, newTestObject()), interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				cancel()
+				return kerrors.NewTooManyRequests("slow down", 10)
+			},
+		})
+
+		// Act
+		err := RetryMergePatch(ctx, c, newTestObject(), setLabel, WithBackoff(testBackoff))
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRetryJSONPatch(t *testing.T) {
+	t.Run("Patch object", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, newTestObject())
+
+		// Act
+		err := RetryJSONPatch(context.Background(), c, newTestObject(), setLabel)
+
+		// Assert
+		assert.NoError(t, err)
+		stored := newTestObject()
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(stored), stored))
+		assert.Equal(t, "value", stored.GetLabels()["key"])
+	})
+
+	t.Run("Skip patch without changes", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		c := interceptor.NewClient(testSupport.CreateFakeClient(t, newTestObject()), conflictOnFirstPatch(&calls))
+
+		// Act
+		err := RetryJSONPatch(context.Background(), c, newTestObject(), nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("Retry if the object was changed concurrently", func(t *testing.T) {
+		// Arrange
+		existing := newTestObject()
+		existing.SetFinalizers([]string{"first", "second"})
+		calls := 0
+		c := interceptor.NewClient(testSupport.CreateFakeClient(t, existing), interceptor.Funcs{
+			Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				calls++
+				if calls == 1 {
+					concurrent := newTestObject()
+					require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(concurrent), concurrent))
+					concurrent.SetFinalizers([]string{"second"})
+					require.NoError(t, cl.Update(ctx, concurrent))
+				}
+				return cl.Patch(ctx, obj, patch, opts...)
+			},
+		})
+
+		// Act
+		err := RetryJSONPatch(context.Background(), c, newTestObject(), func(obj client.Object) error {
+			controllerutil.RemoveFinalizer(obj, "second")
+			return nil
+		}, WithBackoff(testBackoff))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		stored := newTestObject()
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(stored), stored))
+		assert.Empty(t, stored.GetFinalizers())
+	})
+}
+
+func TestRetryApply(t *testing.T) {
+	t.Run("Apply object", func(t *testing.T) {
+		// Arrange
+		var patchType types.PatchType
+		var patchOptions client.PatchOptions
+		c := interceptor.NewClient(testSupport.CreateFakeClient(t, newTestObject()), interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, _ client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchType = patch.Type()
+				patchOptions.ApplyOptions(opts)
+				return nil
+			},
+		})
+
+		// Act
+		err := RetryApply(context.Background(), c, newTestObject(), setLabel, WithFieldOwner("owner"), WithForceOwnership())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, types.ApplyPatchType, patchType)
+		assert.Equal(t, "owner", patchOptions.FieldManager)
+		require.NotNil(t, patchOptions.Force)
+		assert.True(t, *patchOptions.Force)
+	})
+
+	t.Run("Retry conflicts only with force ownership", func(t *testing.T) {
+		for _, force := range []bool{false, true} {
+			// Arrange
+			calls := 0
+			c := interceptor.NewClient(testSupport.CreateFakeClient(t, newTestObject()), conflictOnFirstPatch(&calls))
+			opts := []Option{WithBackoff(testBackoff)}
+			if force {
+				opts = append(opts, WithForceOwnership())
+			}
+
+			// Act
+			err := RetryApply(context.Background(), c, newTestObject(), nil, opts...)
+
+			// Assert
+			if force {
+				assert.Equal(t, 2, calls)
+			} else {
+				assert.True(t, kerrors.IsConflict(err))
+				assert.Equal(t, 1, calls)
+			}
+		}
+	})
+}
+
+func TestRetryCreateOrPatch(t *testing.T) {
+	t.Run("Create object", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, &testSupport.TestApiObject{})
+
+		// Act
+		result, err := RetryCreateOrPatch(context.Background(), c, newTestObject(), setLabel)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, controllerutil.OperationResultCreated, result)
+	})
+
+	t.Run("Patch existing object", func(t *testing.T) {
+		// Arrange
+		c := testSupport.CreateFakeClient(t, newTestObject())
+
+		// Act
+		result, err := RetryCreateOrPatch(context.Background(), c, newTestObject(), setLabel)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, controllerutil.OperationResultUpdated, result)
+	})
+	t.Run("Use the field owner", func(t *testing.T) {
+		// Arrange
+		var fieldManagers []string
+		c := interceptor.NewClient(testSupport.CreateFakeClient(t, &testSupport.TestApiObject{}), interceptor.Funcs{
+			Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				createOptions := &client.CreateOptions{}
+				createOptions.ApplyOptions(opts)
+				fieldManagers = append(fieldManagers, createOptions.FieldManager)
+				return cl.Create(ctx, obj, opts...)
+			},
+		})
+
+		// Act
+		_, err := RetryCreateOrPatch(context.Background(), c, newTestObject(), setLabel, WithFieldOwner("owner"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"owner"}, fieldManagers)
+	})
+
+	t.Run("Reject unsupported options", func(t *testing.T) {
+		for name, opt := range map[string]Option{
+			"status":          WithStatusSubresource(),
+			"optimistic lock": WithOptimisticLock(),
+			"force ownership": WithForceOwnership(),
+		} {
+			// Arrange
+			c := testSupport.CreateFakeClient(t, &testSupport.TestApiObject{})
+
+			// Act
+			result, err := RetryCreateOrPatch(context.Background(), c, newTestObject(), setLabel, opt)
+
+			// Assert
+			assert.Error(t, err, name)
+			assert.Equal(t, controllerutil.OperationResultNone, result, name)
+		}
+	})
+}
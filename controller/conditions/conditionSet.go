@@ -0,0 +1,231 @@
+package conditions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Severity expresses how severe a condition with status False is
+type Severity string
+
+const (
+	SeverityError   Severity = "Error"
+	SeverityWarning Severity = "Warning"
+	SeverityInfo    Severity = "Info"
+	SeverityNone    Severity = ""
+)
+
+const (
+	ConditionReady = "Ready"
+
+	ReasonReady                   = "Ready"
+	ReasonMirroredConditionAbsent = "MirroredConditionAbsent"
+)
+
+// ConditionSet manages multiple condition types of a single object
+//
+// Severities are not part of metav1.Condition. SeverityWarning and SeverityInfo are therefore persisted as a prefix of
+// the message of False conditions, e.g. "[Warning] quota almost exhausted", so they survive across reconciles and can be
+// mirrored. Conditions with status False without such a prefix are treated with SeverityError.
+type ConditionSet interface {
+	SetTrue(conditionType, reason, message string)
+	SetFalse(conditionType string, severity Severity, reason, message string)
+	SetUnknown(conditionType, reason, message string)
+	Delete(conditionType string)
+
+	Get(conditionType string) *metav1.Condition
+	GetSeverity(conditionType string) Severity
+	IsTrue(conditionType string) bool
+	IsFalse(conditionType string) bool
+	IsUnknown(conditionType string) bool
+
+	// SetSummary aggregates the given condition types, or all condition types if none are given, into the Ready condition
+	SetSummary(conditionTypes ...string)
+	// Mirror copies the Ready condition of a child object including its severity into the given condition type
+	Mirror(child metav1.Object, childConditions []metav1.Condition, targetType string)
+}
+
+type conditionSet struct {
+	object     metav1.Object
+	conditions *[]metav1.Condition
+}
+
+// NewConditionSet returns a ConditionSet for the conditions of the given object.
+// The ObservedGeneration of every condition is taken from the object at the time the condition is set.
+// Severities are read from the persisted conditions, so a new ConditionSet in the next reconcile keeps them.
+func NewConditionSet(object metav1.Object, conditions *[]metav1.Condition) ConditionSet {
+	return &conditionSet{
+		object:     object,
+		conditions: conditions,
+	}
+}
+
+func (c *conditionSet) set(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(c.conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: c.object.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func (c *conditionSet) SetTrue(conditionType, reason, message string) {
+	c.set(conditionType, metav1.ConditionTrue, reason, message)
+}
+
+func (c *conditionSet) SetFalse(conditionType string, severity Severity, reason, message string) {
+	c.set(conditionType, metav1.ConditionFalse, reason, encodeSeverity(severity, message))
+}
+
+func (c *conditionSet) SetUnknown(conditionType, reason, message string) {
+	c.set(conditionType, metav1.ConditionUnknown, reason, message)
+}
+
+func (c *conditionSet) Delete(conditionType string) {
+	meta.RemoveStatusCondition(c.conditions, conditionType)
+}
+
+func (c *conditionSet) Get(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(*c.conditions, conditionType)
+}
+
+func (c *conditionSet) GetSeverity(conditionType string) Severity {
+	cond := c.Get(conditionType)
+	if cond == nil || cond.Status != metav1.ConditionFalse {
+		return SeverityNone
+	}
+	severity, _ := decodeSeverity(cond.Message)
+	return severity
+}
+
+// encodeSeverity prefixes the message with severities other than SeverityError, which is the default of False conditions
+func encodeSeverity(severity Severity, message string) string {
+	if severity != SeverityWarning && severity != SeverityInfo {
+		return message
+	}
+	if message == "" {
+		return fmt.Sprintf("[%s]", severity)
+	}
+	return fmt.Sprintf("[%s] %s", severity, message)
+}
+
+// decodeSeverity returns the severity of a False condition message and the message without severity prefix
+func decodeSeverity(message string) (Severity, string) {
+	for _, severity := range []Severity{SeverityWarning, SeverityInfo} {
+		prefix := fmt.Sprintf("[%s]", severity)
+		if rest, ok := strings.CutPrefix(message, prefix); ok && (rest == "" || rest[0] == ' ') {
+			return severity, strings.TrimPrefix(rest, " ")
+		}
+	}
+	return SeverityError, message
+}
+
+func (c *conditionSet) IsTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(*c.conditions, conditionType)
+}
+
+func (c *conditionSet) IsFalse(conditionType string) bool {
+	return meta.IsStatusConditionFalse(*c.conditions, conditionType)
+}
+
+func (c *conditionSet) IsUnknown(conditionType string) bool {
+	return meta.IsStatusConditionPresentAndEqual(*c.conditions, conditionType, metav1.ConditionUnknown)
+}
+
+// SetSummary follows the merge rules of the Cluster API conditions utilities. The Ready condition is False in case
+// any condition is False, Unknown in case any condition is Unknown and True otherwise. Reason and message are taken
+// from the condition with the highest priority, which is False with the highest severity before Unknown before True.
+func (c *conditionSet) SetSummary(conditionTypes ...string) {
+	var candidates []metav1.Condition
+	for _, cond := range *c.conditions {
+		if cond.Type == ConditionReady {
+			continue
+		}
+		if len(conditionTypes) > 0 && !slices.Contains(conditionTypes, cond.Type) {
+			continue
+		}
+		candidates = append(candidates, cond)
+	}
+
+	if len(candidates) == 0 {
+		c.SetTrue(ConditionReady, ReasonReady, "")
+		return
+	}
+
+	top := candidates[0]
+	for _, cond := range candidates[1:] {
+		if c.priority(cond) < c.priority(top) {
+			top = cond
+		}
+	}
+
+	switch top.Status {
+	case metav1.ConditionFalse:
+		c.SetFalse(ConditionReady, c.GetSeverity(top.Type), top.Reason, summaryMessage(top))
+	case metav1.ConditionUnknown:
+		c.SetUnknown(ConditionReady, top.Reason, summaryMessage(top))
+	default:
+		c.SetTrue(ConditionReady, ReasonReady, "")
+	}
+}
+
+// priority returns the merge priority of a condition, lower values win
+func (c *conditionSet) priority(cond metav1.Condition) int {
+	switch cond.Status {
+	case metav1.ConditionFalse:
+		switch c.GetSeverity(cond.Type) {
+		case SeverityError:
+			return 0
+		case SeverityWarning:
+			return 1
+		default:
+			return 2
+		}
+	case metav1.ConditionUnknown:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func summaryMessage(cond metav1.Condition) string {
+	message := cond.Message
+	if cond.Status == metav1.ConditionFalse {
+		_, message = decodeSeverity(message)
+	}
+	if message == "" {
+		return cond.Type
+	}
+	return fmt.Sprintf("%s: %s", cond.Type, message)
+}
+
+func (c *conditionSet) Mirror(child metav1.Object, childConditions []metav1.Condition, targetType string) {
+	source := meta.FindStatusCondition(childConditions, ConditionReady)
+	if source == nil {
+		c.SetUnknown(targetType, ReasonMirroredConditionAbsent, fmt.Sprintf("%s has no %s condition", child.GetName(), ConditionReady))
+		return
+	}
+
+	message := source.Message
+	severity := SeverityNone
+	if source.Status == metav1.ConditionFalse {
+		severity, message = decodeSeverity(message)
+	}
+	if message != "" {
+		message = fmt.Sprintf("%s: %s", child.GetName(), message)
+	}
+
+	switch source.Status {
+	case metav1.ConditionTrue:
+		c.SetTrue(targetType, source.Reason, message)
+	case metav1.ConditionFalse:
+		c.SetFalse(targetType, severity, source.Reason, message)
+	default:
+		c.SetUnknown(targetType, source.Reason, message)
+	}
+}
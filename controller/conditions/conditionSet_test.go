@@ -0,0 +1,241 @@
+package conditions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionSet(t *testing.T) {
+	t.Run("Set conditions with the generation of the live object", func(t *testing.T) {
+		// Arrange
+		obj := &metav1.ObjectMeta{Generation: 3}
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(obj, &conditions)
+
+		// Act
+		set.SetTrue("A", "Done", "a is done")
+		obj.Generation = 4
+		set.SetFalse("B", SeverityWarning, "Failed", "b failed")
+		set.SetUnknown("C", "Pending", "c is pending")
+
+		// Assert
+		require.Len(t, conditions, 3)
+		assert.Equal(t, int64(3), set.Get("A").ObservedGeneration)
+		assert.Equal(t, int64(4), set.Get("B").ObservedGeneration)
+		assert.True(t, set.IsTrue("A"))
+		assert.True(t, set.IsFalse("B"))
+		assert.True(t, set.IsUnknown("C"))
+		assert.Equal(t, SeverityWarning, set.GetSeverity("B"))
+		assert.Equal(t, SeverityNone, set.GetSeverity("A"))
+	})
+
+	t.Run("Severity defaults to error for unmanaged false conditions", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{{Type: "A", Status: metav1.ConditionFalse, Reason: "Failed"}}
+
+		// Act
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+
+		// Assert
+		assert.Equal(t, SeverityError, set.GetSeverity("A"))
+		assert.Equal(t, SeverityNone, set.GetSeverity("missing"))
+	})
+
+	t.Run("Severity survives a new condition set", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		NewConditionSet(&metav1.ObjectMeta{}, &conditions).SetFalse("A", SeverityWarning, "Failed", "a failed")
+		NewConditionSet(&metav1.ObjectMeta{}, &conditions).SetFalse("B", SeverityInfo, "Failed", "")
+
+		// Act
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+
+		// Assert
+		assert.Equal(t, SeverityWarning, set.GetSeverity("A"))
+		assert.Equal(t, "[Warning] a failed", set.Get("A").Message)
+		assert.Equal(t, SeverityInfo, set.GetSeverity("B"))
+		assert.Equal(t, "[Info]", set.Get("B").Message)
+	})
+
+	t.Run("Delete condition", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		set.SetFalse("A", SeverityInfo, "Failed", "")
+
+		// Act
+		set.Delete("A")
+
+		// Assert
+		assert.Empty(t, conditions)
+		assert.Nil(t, set.Get("A"))
+	})
+}
+
+func TestConditionSetSummary(t *testing.T) {
+	t.Run("Ready is true without conditions", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+
+		// Act
+		set.SetSummary()
+
+		// Assert
+		assert.True(t, set.IsTrue(ConditionReady))
+	})
+
+	t.Run("Ready is true when all conditions are true", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		set.SetTrue("A", "Done", "")
+		set.SetTrue("B", "Done", "")
+
+		// Act
+		set.SetSummary()
+
+		// Assert
+		assert.True(t, set.IsTrue(ConditionReady))
+		assert.Equal(t, ReasonReady, set.Get(ConditionReady).Reason)
+	})
+
+	t.Run("Ready takes the false condition with the highest severity", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		set.SetUnknown("A", "Pending", "a is pending")
+		set.SetFalse("B", SeverityInfo, "Info", "b info")
+		set.SetFalse("C", SeverityError, "Broken", "c is broken")
+		set.SetFalse("D", SeverityWarning, "Warning", "d warning")
+
+		// Act
+		set.SetSummary()
+
+		// Assert
+		ready := set.Get(ConditionReady)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, "Broken", ready.Reason)
+		assert.Equal(t, "C: c is broken", ready.Message)
+		assert.Equal(t, SeverityError, set.GetSeverity(ConditionReady))
+	})
+
+	t.Run("Ready keeps the severity in the next reconcile", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		set.SetFalse("A", SeverityWarning, "Warning", "a warning")
+		set.SetTrue("B", "Done", "")
+
+		// Act
+		set = NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		set.SetSummary()
+
+		// Assert
+		ready := set.Get(ConditionReady)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, "[Warning] A: a warning", ready.Message)
+		assert.Equal(t, SeverityWarning, set.GetSeverity(ConditionReady))
+	})
+
+	t.Run("Ready is unknown when no condition is false", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		set.SetTrue("A", "Done", "")
+		set.SetUnknown("B", "Pending", "")
+
+		// Act
+		set.SetSummary()
+
+		// Assert
+		ready := set.Get(ConditionReady)
+		assert.Equal(t, metav1.ConditionUnknown, ready.Status)
+		assert.Equal(t, "Pending", ready.Reason)
+		assert.Equal(t, "B", ready.Message)
+	})
+
+	t.Run("Ready only considers the given condition types", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		set.SetTrue("A", "Done", "")
+		set.SetFalse("B", SeverityError, "Broken", "")
+
+		// Act
+		set.SetSummary("A")
+
+		// Assert
+		assert.True(t, set.IsTrue(ConditionReady))
+	})
+}
+
+func TestConditionSetMirror(t *testing.T) {
+	child := &metav1.ObjectMeta{Name: "child"}
+
+	t.Run("Mirror the ready condition of a child", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{Generation: 2}, &conditions)
+		childConditions := []metav1.Condition{{Type: ConditionReady, Status: metav1.ConditionFalse, Reason: "Broken", Message: "it broke"}}
+
+		// Act
+		set.Mirror(child, childConditions, "ChildReady")
+
+		// Assert
+		mirrored := set.Get("ChildReady")
+		require.NotNil(t, mirrored)
+		assert.Equal(t, metav1.ConditionFalse, mirrored.Status)
+		assert.Equal(t, "Broken", mirrored.Reason)
+		assert.Equal(t, "child: it broke", mirrored.Message)
+		assert.Equal(t, int64(2), mirrored.ObservedGeneration)
+		assert.Equal(t, SeverityError, set.GetSeverity("ChildReady"))
+	})
+
+	t.Run("Mirror the severity of a child", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+		childConditions := []metav1.Condition{}
+		childSet := NewConditionSet(child, &childConditions)
+		childSet.SetFalse("Quota", SeverityWarning, "QuotaLow", "almost exhausted")
+		childSet.SetSummary()
+
+		// Act
+		set.Mirror(child, childConditions, "ChildReady")
+
+		// Assert
+		assert.Equal(t, SeverityWarning, set.GetSeverity("ChildReady"))
+		assert.Equal(t, "[Warning] child: Quota: almost exhausted", set.Get("ChildReady").Message)
+	})
+
+	t.Run("Mirror true and unknown ready conditions", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+
+		// Act
+		set.Mirror(child, []metav1.Condition{{Type: ConditionReady, Status: metav1.ConditionTrue, Reason: "Ready"}}, "First")
+		set.Mirror(child, []metav1.Condition{{Type: ConditionReady, Status: metav1.ConditionUnknown, Reason: "Pending"}}, "Second")
+
+		// Assert
+		assert.True(t, set.IsTrue("First"))
+		assert.True(t, set.IsUnknown("Second"))
+	})
+
+	t.Run("Mirror a child without ready condition", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{}
+		set := NewConditionSet(&metav1.ObjectMeta{}, &conditions)
+
+		// Act
+		set.Mirror(child, nil, "ChildReady")
+
+		// Assert
+		assert.True(t, set.IsUnknown("ChildReady"))
+		assert.Equal(t, ReasonMirroredConditionAbsent, set.Get("ChildReady").Reason)
+	})
+}
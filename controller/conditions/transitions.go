@@ -0,0 +1,64 @@
+package conditions
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Transition describes the change of a single condition type.
+// OldStatus is empty for added conditions, NewStatus is empty for removed conditions.
+type Transition struct {
+	Type      string
+	OldStatus metav1.ConditionStatus
+	NewStatus metav1.ConditionStatus
+	OldReason string
+	Reason    string
+	Message   string
+}
+
+// StatusChanged returns true if the status of the condition changed, in contrast to only its reason
+func (t Transition) StatusChanged() bool {
+	return t.OldStatus != t.NewStatus
+}
+
+// Diff compares two condition slices and returns a transition for every condition that was added, removed or
+// changed its status or reason. Transitions are ordered like the new conditions, followed by removed conditions.
+func Diff(oldConditions, newConditions []metav1.Condition) []Transition {
+	var transitions []Transition
+	for _, newCondition := range newConditions {
+		oldCondition := meta.FindStatusCondition(oldConditions, newCondition.Type)
+		if oldCondition == nil {
+			transitions = append(transitions, Transition{
+				Type:      newCondition.Type,
+				NewStatus: newCondition.Status,
+				Reason:    newCondition.Reason,
+				Message:   newCondition.Message,
+			})
+			continue
+		}
+		if oldCondition.Status == newCondition.Status && oldCondition.Reason == newCondition.Reason {
+			continue
+		}
+		transitions = append(transitions, Transition{
+			Type:      newCondition.Type,
+			OldStatus: oldCondition.Status,
+			NewStatus: newCondition.Status,
+			OldReason: oldCondition.Reason,
+			Reason:    newCondition.Reason,
+			Message:   newCondition.Message,
+		})
+	}
+
+	for _, oldCondition := range oldConditions {
+		if meta.FindStatusCondition(newConditions, oldCondition.Type) != nil {
+			continue
+		}
+		transitions = append(transitions, Transition{
+			Type:      oldCondition.Type,
+			OldStatus: oldCondition.Status,
+			OldReason: oldCondition.Reason,
+		})
+	}
+
+	return transitions
+}
@@ -0,0 +1,49 @@
+package conditions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("No transitions for equal conditions", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{{Type: "A", Status: metav1.ConditionTrue, Reason: "Done", Message: "old"}}
+		updated := []metav1.Condition{{Type: "A", Status: metav1.ConditionTrue, Reason: "Done", Message: "new"}}
+
+		// Act
+		transitions := Diff(conditions, updated)
+
+		// Assert
+		assert.Empty(t, transitions)
+	})
+
+	t.Run("Added, changed and removed conditions", func(t *testing.T) {
+		// Arrange
+		conditions := []metav1.Condition{
+			{Type: "Changed", Status: metav1.ConditionUnknown, Reason: "Processing"},
+			{Type: "Removed", Status: metav1.ConditionTrue, Reason: "Done"},
+			{Type: "Reason", Status: metav1.ConditionFalse, Reason: "First"},
+		}
+		updated := []metav1.Condition{
+			{Type: "Changed", Status: metav1.ConditionFalse, Reason: "Error", Message: "failed"},
+			{Type: "Added", Status: metav1.ConditionTrue, Reason: "Done"},
+			{Type: "Reason", Status: metav1.ConditionFalse, Reason: "Second"},
+		}
+
+		// Act
+		transitions := Diff(conditions, updated)
+
+		// Assert
+		require.Len(t, transitions, 4)
+		assert.Equal(t, Transition{Type: "Changed", OldStatus: metav1.ConditionUnknown, NewStatus: metav1.ConditionFalse, OldReason: "Processing", Reason: "Error", Message: "failed"}, transitions[0])
+		assert.True(t, transitions[0].StatusChanged())
+		assert.Equal(t, Transition{Type: "Added", NewStatus: metav1.ConditionTrue, Reason: "Done"}, transitions[1])
+		assert.Equal(t, Transition{Type: "Reason", OldStatus: metav1.ConditionFalse, NewStatus: metav1.ConditionFalse, OldReason: "First", Reason: "Second"}, transitions[2])
+		assert.False(t, transitions[2].StatusChanged())
+		assert.Equal(t, Transition{Type: "Removed", OldStatus: metav1.ConditionTrue, OldReason: "Done"}, transitions[3])
+	})
+}
@@ -0,0 +1,132 @@
+package errors
+
+import (
+	goerrors "errors"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	operrors "github.com/openmfp/golang-commons/errors"
+)
+
+// ErrorClass is the structured outcome of a failed call against the Kubernetes API
+type ErrorClass string
+
+const (
+	ClassTransient            ErrorClass = "Transient"
+	ClassConflict             ErrorClass = "Conflict"
+	ClassTerminal             ErrorClass = "Terminal"
+	ClassWebhookDenied        ErrorClass = "WebhookDenied"
+	ClassQuotaExceeded        ErrorClass = "QuotaExceeded"
+	ClassNamespaceTerminating ErrorClass = "NamespaceTerminating"
+	ClassForbidden            ErrorClass = "Forbidden"
+	ClassNotFound             ErrorClass = "NotFound"
+	// ClassUnknown is used for errors which do not originate from the Kubernetes API
+	ClassUnknown ErrorClass = "Unknown"
+)
+
+// Policy defines how errors of a class are handled
+type Policy struct {
+	Retry  bool
+	Sentry bool
+	// Reason is meant to be used as condition reason
+	Reason string
+}
+
+// Classification is the result of classifying an error
+type Classification struct {
+	Policy
+	Class ErrorClass
+	// RequeueAfter is set if the API server suggested a delay before retrying
+	RequeueAfter time.Duration
+}
+
+// DefaultPolicies contains the policy applied for each ErrorClass
+var DefaultPolicies = map[ErrorClass]Policy{
+	ClassTransient:            {Retry: true, Sentry: false, Reason: "TransientError"},
+	ClassConflict:             {Retry: true, Sentry: false, Reason: "Conflict"},
+	ClassTerminal:             {Retry: false, Sentry: true, Reason: "TerminalError"},
+	ClassWebhookDenied:        {Retry: false, Sentry: false, Reason: "WebhookDenied"},
+	ClassQuotaExceeded:        {Retry: true, Sentry: false, Reason: "QuotaExceeded"},
+	ClassNamespaceTerminating: {Retry: false, Sentry: false, Reason: "NamespaceTerminating"},
+	ClassForbidden:            {Retry: true, Sentry: true, Reason: "Forbidden"},
+	ClassNotFound:             {Retry: true, Sentry: false, Reason: "NotFound"},
+	ClassUnknown:              {Retry: true, Sentry: true, Reason: "Error"},
+}
+
+// Classify maps an error to its ErrorClass and the corresponding default policy
+func Classify(err error) Classification {
+	class := classOf(err)
+	classification := Classification{
+		Policy: DefaultPolicies[class],
+		Class:  class,
+	}
+	if delay, ok := k8sErrors.SuggestsClientDelay(err); ok {
+		classification.RequeueAfter = time.Duration(delay) * time.Second
+	}
+	return classification
+}
+
+func classOf(err error) ErrorClass {
+	var status k8sErrors.APIStatus
+	if !goerrors.As(err, &status) {
+		return ClassUnknown
+	}
+
+	// Order matters, e.g. a terminating namespace and an exceeded quota are reported as forbidden
+	switch {
+	case k8sErrors.HasStatusCause(err, v1.NamespaceTerminatingCause):
+		return ClassNamespaceTerminating
+	case isWebhookDenied(err):
+		return ClassWebhookDenied
+	case isQuotaExceeded(err):
+		return ClassQuotaExceeded
+	case k8sErrors.IsConflict(err):
+		return ClassConflict
+	case k8sErrors.IsNotFound(err):
+		return ClassNotFound
+	case k8sErrors.IsForbidden(err), k8sErrors.IsUnauthorized(err):
+		return ClassForbidden
+	case isTransient(err):
+		return ClassTransient
+	default:
+		return ClassTerminal
+	}
+}
+
+func isTransient(err error) bool {
+	if _, ok := k8sErrors.SuggestsClientDelay(err); ok {
+		return true
+	}
+	return k8sErrors.IsInternalError(err) ||
+		k8sErrors.IsServiceUnavailable(err) ||
+		k8sErrors.IsTooManyRequests(err) ||
+		k8sErrors.IsTimeout(err) ||
+		k8sErrors.IsServerTimeout(err) ||
+		k8sErrors.IsUnexpectedServerError(err)
+}
+
+func isWebhookDenied(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "admission webhook") && strings.Contains(msg, "denied the request")
+}
+
+func isQuotaExceeded(err error) bool {
+	return k8sErrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota")
+}
+
+// ToOperatorError turns any error into an OperatorError using the default policy of its ErrorClass.
+// The reason of the policy and a delay suggested by the API server are added to the OperatorError.
+func ToOperatorError(err error, opts ...operrors.OperatorErrorOption) operrors.OperatorError {
+	if err == nil {
+		return nil
+	}
+	classification := Classify(err)
+	opts = append([]operrors.OperatorErrorOption{
+		operrors.WithReason(classification.Reason),
+		operrors.WithRequeueAfter(classification.RequeueAfter),
+	}, opts...)
+	return operrors.NewOperatorError(err, classification.Retry, classification.Sentry, opts...)
+}
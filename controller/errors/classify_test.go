@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	operrors "github.com/openmfp/golang-commons/errors"
+)
+
+func TestClassify(t *testing.T) {
+	gr := schema.GroupResource{Group: "core.openmfp.io", Resource: "accounts"}
+
+	terminatingNamespace := k8sErrors.NewForbidden(gr, "test", fmt.Errorf("namespace is being terminated"))
+	terminatingNamespace.ErrStatus.Details.Causes = []metav1.StatusCause{{Type: v1.NamespaceTerminatingCause}}
+
+	tests := []struct {
+		name         string
+		err          error
+		class        ErrorClass
+		retry        bool
+		sentry       bool
+		requeueAfter time.Duration
+	}{
+		{name: "unknown error", err: fmt.Errorf("oh nose"), class: ClassUnknown, retry: true, sentry: true},
+		{name: "service unavailable", err: k8sErrors.NewServiceUnavailable("na"), class: ClassTransient, retry: true},
+		{name: "internal error", err: k8sErrors.NewInternalError(fmt.Errorf("oh nose")), class: ClassTransient, retry: true},
+		{name: "too many requests", err: k8sErrors.NewTooManyRequests("slow down", 5), class: ClassTransient, retry: true, requeueAfter: 5 * time.Second},
+		{name: "too many requests without delay", err: k8sErrors.NewTooManyRequests("slow down", 0), class: ClassTransient, retry: true},
+		{name: "timeout without delay", err: k8sErrors.NewTimeoutError("timeout", 0), class: ClassTransient, retry: true},
+		{name: "server timeout", err: k8sErrors.NewServerTimeout(gr, "get", 0), class: ClassTransient, retry: true},
+		{name: "gateway timeout", err: k8sErrors.NewGenericServerResponse(http.StatusGatewayTimeout, "get", gr, "test", "", 0, false), class: ClassTransient, retry: true},
+		{name: "unexpected server error", err: k8sErrors.NewGenericServerResponse(http.StatusBadGateway, "get", gr, "test", "", 0, true), class: ClassTransient, retry: true},
+		{name: "conflict", err: k8sErrors.NewConflict(gr, "test", fmt.Errorf("conflict")), class: ClassConflict, retry: true},
+		{name: "wrapped conflict", err: fmt.Errorf("update failed: %w", k8sErrors.NewConflict(gr, "test", fmt.Errorf("conflict"))), class: ClassConflict, retry: true},
+		{name: "not found", err: k8sErrors.NewNotFound(gr, "test"), class: ClassNotFound, retry: true},
+		{name: "forbidden", err: k8sErrors.NewForbidden(gr, "test", fmt.Errorf("no rbac")), class: ClassForbidden, retry: true, sentry: true},
+		{name: "unauthorized", err: k8sErrors.NewUnauthorized("no token"), class: ClassForbidden, retry: true, sentry: true},
+		{name: "quota exceeded", err: k8sErrors.NewForbidden(gr, "test", fmt.Errorf("exceeded quota: compute-resources")), class: ClassQuotaExceeded, retry: true},
+		{name: "namespace terminating", err: terminatingNamespace, class: ClassNamespaceTerminating},
+		{name: "webhook denied", err: k8sErrors.NewBadRequest(`admission webhook "validate.openmfp.io" denied the request: invalid`), class: ClassWebhookDenied},
+		{name: "invalid", err: k8sErrors.NewInvalid(schema.GroupKind{Kind: "Account"}, "test", nil), class: ClassTerminal, sentry: true},
+		{name: "bad request", err: k8sErrors.NewBadRequest("bad"), class: ClassTerminal, sentry: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			classification := Classify(test.err)
+
+			// Assert
+			assert.Equal(t, test.class, classification.Class)
+			assert.Equal(t, test.retry, classification.Retry)
+			assert.Equal(t, test.sentry, classification.Sentry)
+			assert.Equal(t, DefaultPolicies[test.class].Reason, classification.Reason)
+			assert.Equal(t, test.requeueAfter, classification.RequeueAfter)
+		})
+	}
+}
+
+func TestToOperatorError(t *testing.T) {
+	t.Run("Uses the policy of the error class", func(t *testing.T) {
+		// Arrange
+		err := k8sErrors.NewConflict(schema.GroupResource{}, "test", fmt.Errorf("conflict"))
+
+		// Act
+		operatorErr := ToOperatorError(err)
+
+		// Assert
+		assert.Equal(t, err, operatorErr.Err())
+		assert.True(t, operatorErr.Retry())
+		assert.False(t, operatorErr.Sentry())
+		detailed, ok := operrors.AsDetailedOperatorError(operatorErr)
+		assert.True(t, ok)
+		assert.Equal(t, "Conflict", detailed.Reason())
+	})
+
+	t.Run("Adds the suggested delay and allows overwriting details", func(t *testing.T) {
+		// Arrange
+		err := k8sErrors.NewTooManyRequests("slow down", 5)
+
+		// Act
+		operatorErr := ToOperatorError(err, operrors.WithReason("Throttled"))
+
+		// Assert
+		detailed, ok := operrors.AsDetailedOperatorError(operatorErr)
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Second, detailed.RequeueAfter())
+		assert.Equal(t, "Throttled", detailed.Reason())
+	})
+
+	t.Run("Returns nil for nil errors", func(t *testing.T) {
+		assert.Nil(t, ToOperatorError(nil))
+	})
+}
@@ -1,12 +1,16 @@
 package lifecycle
 
 import (
+	"context"
 	"fmt"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/openmfp/golang-commons/controller/conditions"
+	"github.com/openmfp/golang-commons/errors"
 	"github.com/openmfp/golang-commons/logger"
 	"github.com/openmfp/golang-commons/sentry"
 )
@@ -35,6 +39,43 @@ func (l *LifecycleManager) WithConditionManagement() *LifecycleManager {
 	return l
 }
 
+// ConditionTransitionHook is called with the condition transitions of a reconciliation after the status was updated.
+// It is not called if the status update fails or in read-only mode. It can be used to emit events, metrics or webhooks.
+type ConditionTransitionHook func(ctx context.Context, instance RuntimeObject, transitions []conditions.Transition)
+
+// WithConditionTransitionHooks registers hooks which are notified about condition transitions, requires condition management
+func (l *LifecycleManager) WithConditionTransitionHooks(hooks ...ConditionTransitionHook) *LifecycleManager {
+	l.conditionTransitionHooks = append(l.conditionTransitionHooks, hooks...)
+	return l
+}
+
+// notifyConditionTransitions passes the transitions between the original and the current conditions to the registered hooks
+func (l *LifecycleManager) notifyConditionTransitions(ctx context.Context, originalConditions []metav1.Condition, instance RuntimeObject, log *logger.Logger) {
+	if !l.manageConditions || len(l.conditionTransitionHooks) == 0 {
+		return
+	}
+
+	transitions := conditions.Diff(originalConditions, MustToRuntimeObjectConditionsInterface(instance, log).GetConditions())
+	if len(transitions) == 0 {
+		return
+	}
+
+	for _, hook := range l.conditionTransitionHooks {
+		hook(ctx, instance, transitions)
+	}
+}
+
+func logConditionTransitions(log *logger.Logger, oldConditions, newConditions []metav1.Condition) {
+	for _, transition := range conditions.Diff(oldConditions, newConditions) {
+		log.Info().
+			Str("type", transition.Type).
+			Str("old_status", string(transition.OldStatus)).
+			Str("new_status", string(transition.NewStatus)).
+			Str("reason", transition.Reason).
+			Msg("updated condition")
+	}
+}
+
 type RuntimeObjectConditions interface {
 	GetConditions() []metav1.Condition
 	SetConditions([]metav1.Condition)
@@ -73,10 +114,11 @@ func setSubroutineConditionToUnknownIfNotSet(conditions *[]metav1.Condition, sub
 
 	existingCondition := meta.FindStatusCondition(*conditions, conditionName)
 	if existingCondition == nil {
+		previous := slices.Clone(*conditions)
 		changed := meta.SetStatusCondition(conditions,
 			metav1.Condition{Type: conditionName, Status: metav1.ConditionUnknown, Message: fmt.Sprintf(subroutineMessageProcessingFormatString, conditionMessage), Reason: reasonProcessing})
 		if changed {
-			log.Info().Str("type", conditionName).Msg("updated condition")
+			logConditionTransitions(log, previous, *conditions)
 		}
 		return changed
 	}
@@ -94,7 +136,7 @@ func getConditionNameAndMessage(subroutine Subroutine, isFinalize bool) (string,
 }
 
 // Set Subroutines Conditions
-func setSubroutineCondition(conditions *[]metav1.Condition, subroutine Subroutine, subroutineResult ctrl.Result, subroutineErr error, isFinalize bool, log *logger.Logger) bool {
+func setSubroutineCondition(conditions *[]metav1.Condition, subroutine Subroutine, subroutineResult ctrl.Result, subroutineErr errors.OperatorError, isFinalize bool, log *logger.Logger) bool {
 	conditionName, conditionMessage := getConditionNameAndMessage(subroutine, isFinalize)
 
 	// processing complete
@@ -108,14 +150,20 @@ func setSubroutineCondition(conditions *[]metav1.Condition, subroutine Subroutin
 			metav1.Condition{Type: conditionName, Status: metav1.ConditionUnknown, Message: fmt.Sprintf(subroutineMessageProcessingFormatString, conditionMessage), Reason: reasonProcessing})
 	}
 	// processing failed
-	var sErr error
-	if subroutineErr != nil {
-		sErr = subroutineErr
+	reason, errMessage := reasonError, fmt.Sprint(subroutineErr.Err())
+	if detailed, ok := errors.AsDetailedOperatorError(subroutineErr); ok {
+		if detailed.Reason() != "" {
+			reason = detailed.Reason()
+		}
+		if detailed.UserMessage() != "" {
+			errMessage = detailed.UserMessage()
+		}
 	}
+	previous := slices.Clone(*conditions)
 	changed := meta.SetStatusCondition(conditions,
-		metav1.Condition{Type: conditionName, Status: metav1.ConditionFalse, Message: fmt.Sprintf(subroutineMessageErrorFormatString, conditionMessage, sErr), Reason: reasonError})
+		metav1.Condition{Type: conditionName, Status: metav1.ConditionFalse, Message: fmt.Sprintf(subroutineMessageErrorFormatString, conditionMessage, errMessage), Reason: reason})
 	if changed {
-		log.Info().Str("type", conditionName).Msg("updated condition")
+		logConditionTransitions(log, previous, *conditions)
 	}
 	return changed
 }
@@ -11,6 +11,7 @@ import (
 	controllerruntime "sigs.k8s.io/controller-runtime"
 
 	"github.com/openmfp/golang-commons/controller/testSupport"
+	operrors "github.com/openmfp/golang-commons/errors"
 	"github.com/openmfp/golang-commons/logger"
 )
 
@@ -191,13 +192,30 @@ func TestSubroutineCondition(t *testing.T) {
 		subroutine := changeStatusSubroutine{}
 
 		// When
-		setSubroutineCondition(&condition, subroutine, controllerruntime.Result{}, errors.New("failed"), false, log)
+		setSubroutineCondition(&condition, subroutine, controllerruntime.Result{}, operrors.NewOperatorError(errors.New("failed"), true, false), false, log)
 
 		// Then
 		assert.Equal(t, 1, len(condition))
 		assert.Equal(t, metav1.ConditionFalse, condition[0].Status)
 	})
 
+	t.Run("TestSetSubroutineConditionErrorWithReasonAndUserMessage", func(t *testing.T) {
+		// Given
+		condition := []metav1.Condition{}
+		subroutine := changeStatusSubroutine{}
+		opErr := operrors.NewOperatorError(errors.New("internal details"), true, false,
+			operrors.WithReason("QuotaExceeded"), operrors.WithUserMessage("the quota is exceeded"))
+
+		// When
+		setSubroutineCondition(&condition, subroutine, controllerruntime.Result{}, opErr, false, log)
+
+		// Then
+		require.Equal(t, 1, len(condition))
+		assert.Equal(t, metav1.ConditionFalse, condition[0].Status)
+		assert.Equal(t, "QuotaExceeded", condition[0].Reason)
+		assert.Equal(t, "The subroutine has an error: the quota is exceeded", condition[0].Message)
+	})
+
 	// Add a test case to set a subroutine condition for isFinalize true
 	t.Run("TestSetSubroutineFinalizeConditionReady", func(t *testing.T) {
 		// Given
@@ -233,7 +251,7 @@ func TestSubroutineCondition(t *testing.T) {
 		subroutine := changeStatusSubroutine{}
 
 		// When
-		setSubroutineCondition(&condition, subroutine, controllerruntime.Result{}, errors.New("failed"), true, log)
+		setSubroutineCondition(&condition, subroutine, controllerruntime.Result{}, operrors.NewOperatorError(errors.New("failed"), true, false), true, log)
 
 		// Then
 		assert.Equal(t, 1, len(condition))
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
+	sentrygo "github.com/getsentry/sentry-go"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"golang.org/x/exp/maps"
@@ -21,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/openmfp/golang-commons/context/keys"
 	"github.com/openmfp/golang-commons/controller/filter"
 	"github.com/openmfp/golang-commons/errors"
 	"github.com/openmfp/golang-commons/logger"
@@ -37,6 +40,8 @@ type LifecycleManager struct {
 	manageConditions   bool
 	readOnly           bool
 	prepareContextFunc PrepareContextFunc
+
+	conditionTransitionHooks []ConditionTransitionHook
 }
 
 type RuntimeObject interface {
@@ -71,11 +76,14 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 	result := ctrl.Result{}
 	reconcileId := uuid.New().String()
 
-	log := l.log.MustChildLoggerWithAttributes("name", req.Name, "namespace", req.Namespace, "reconcile_id", reconcileId)
-	sentryTags := sentry.Tags{"namespace": req.Namespace, "name": req.Name}
-
+	// a hub per reconcile collects the log events of the reconcile as breadcrumbs of captured errors, the logger
+	// loaded from the context carries the hub
+	ctx = sentrygo.SetHubOnContext(ctx, reconcileHub(ctx))
+	log := l.log.MustChildLoggerWithAttributes("name", req.Name, "namespace", req.Namespace, logger.ReconcileIdLoggerKey, reconcileId)
+	log = logger.LoadLoggerFromContext(logger.SetLoggerInContext(ctx, log))
 	ctx = logger.SetLoggerInContext(ctx, log)
-	ctx = sentry.ContextWithSentryTags(ctx, sentryTags)
+	ctx = context.WithValue(ctx, keys.ReconcileIdCtxKey, reconcileId)
+	ctx = sentry.ContextWithSentryTags(ctx, sentry.Tags{"namespace": req.Namespace, "name": req.Name})
 
 	log.Info().Msg("start reconcile")
 	generationChanged := true
@@ -85,7 +93,7 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 			log.Info().Msg("instance not found. It was likely deleted")
 			return ctrl.Result{}, nil
 		}
-		return l.handleClientError("failed to retrieve instance", log, err, generationChanged, sentryTags)
+		return l.handleClientError(ctx, "failed to retrieve instance", log, err, generationChanged)
 	}
 
 	originalCopy := instance.DeepCopyObject()
@@ -110,9 +118,10 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 		return ctrl.Result{}, ferr
 	}
 
-	var conditions []v1.Condition
+	var conditions, originalConditions []v1.Condition
 	if l.manageConditions {
 		conditions = MustToRuntimeObjectConditionsInterface(instance, log).GetConditions()
+		originalConditions = slices.Clone(conditions)
 		setInstanceConditionUnknownIfNotSet(&conditions)
 	}
 
@@ -141,14 +150,15 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 		if l.manageConditions {
 			MustToRuntimeObjectConditionsInterface(instance, log).SetConditions(conditions)
 		}
-		subResult, retry, err := l.reconcileSubroutine(ctx, instance, subroutine, log, generationChanged, sentryTags)
+		subResult, opErr := l.reconcileSubroutine(ctx, instance, subroutine, log, generationChanged)
 		// Update conditions with any changes the subroutine did
 		if l.manageConditions {
 			conditions = MustToRuntimeObjectConditionsInterface(instance, log).GetConditions()
 		}
-		if err != nil {
+		if opErr != nil && opErr.Err() != nil {
+			retry := opErr.Retry()
 			if l.manageConditions {
-				setSubroutineCondition(&conditions, subroutine, result, err, inDeletion, log)
+				setSubroutineCondition(&conditions, subroutine, result, opErr, inDeletion, log)
 				setInstanceConditionReady(&conditions, v1.ConditionFalse)
 				MustToRuntimeObjectConditionsInterface(instance, log).SetConditions(conditions)
 			}
@@ -156,12 +166,18 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 				l.markResourceAsFinal(instance, log, conditions, v1.ConditionFalse)
 			}
 			if !l.readOnly {
-				_ = updateStatus(ctx, l.client, originalCopy, instance, log, generationChanged, sentryTags)
+				// transitions are only notified once they are persisted
+				if updateErr := updateStatus(ctx, l.client, originalCopy, instance, log, generationChanged); updateErr == nil {
+					l.notifyConditionTransitions(ctx, originalConditions, instance, log)
+				}
 			}
 			if !retry {
 				return ctrl.Result{}, nil
 			}
-			return subResult, err
+			if requeueAfter := requeueAfterFromOperatorError(opErr); requeueAfter > 0 {
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			}
+			return subResult, opErr.Err()
 		}
 		if subResult.Requeue {
 			result.Requeue = subResult.Requeue
@@ -173,7 +189,7 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 		}
 		if l.manageConditions {
 			if !subResult.Requeue && subResult.RequeueAfter == 0 {
-				setSubroutineCondition(&conditions, subroutine, subResult, err, inDeletion, log)
+				setSubroutineCondition(&conditions, subroutine, subResult, nil, inDeletion, log)
 			}
 		}
 	}
@@ -192,10 +208,11 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 	}
 
 	if !l.readOnly {
-		err = updateStatus(ctx, l.client, originalCopy, instance, log, generationChanged, sentryTags)
+		err = updateStatus(ctx, l.client, originalCopy, instance, log, generationChanged)
 		if err != nil {
 			return result, err
 		}
+		l.notifyConditionTransitions(ctx, originalConditions, instance, log)
 	}
 
 	if l.spreadReconciles && instance.GetDeletionTimestamp().IsZero() {
@@ -204,7 +221,7 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 		if removed {
 			updateErr := l.client.Patch(ctx, instance, client.MergeFrom(original))
 			if updateErr != nil {
-				return l.handleClientError("failed to update instance", log, err, generationChanged, sentryTags)
+				return l.handleClientError(ctx, "failed to update instance", log, err, generationChanged)
 			}
 		}
 	}
@@ -213,6 +230,14 @@ func (l *LifecycleManager) Reconcile(ctx context.Context, req ctrl.Request, inst
 	return result, nil
 }
 
+// reconcileHub clones the hub of the context or the current hub, so breadcrumbs are not shared between reconciles
+func reconcileHub(ctx context.Context) *sentrygo.Hub {
+	if hub := sentrygo.GetHubFromContext(ctx); hub != nil {
+		return hub.Clone()
+	}
+	return sentrygo.CurrentHub().Clone()
+}
+
 func (l *LifecycleManager) markResourceAsFinal(instance RuntimeObject, log *logger.Logger, conditions []v1.Condition, status v1.ConditionStatus) {
 	if l.spreadReconciles && instance.GetDeletionTimestamp().IsZero() {
 		instanceStatusObj := MustToRuntimeObjectSpreadReconcileStatusInterface(instance, log)
@@ -241,7 +266,7 @@ func (l *LifecycleManager) validateInterfaces(instance RuntimeObject, log *logge
 	return nil
 }
 
-func updateStatus(ctx context.Context, cl client.Client, original runtime.Object, current RuntimeObject, log *logger.Logger, generationChanged bool, sentryTags sentry.Tags) error {
+func updateStatus(ctx context.Context, cl client.Client, original runtime.Object, current RuntimeObject, log *logger.Logger, generationChanged bool) error {
 	currentUn, err := runtime.DefaultUnstructuredConverter.ToUnstructured(current)
 	if err != nil {
 		return err
@@ -279,7 +304,7 @@ func updateStatus(ctx context.Context, cl client.Client, original runtime.Object
 		if !kerrors.IsConflict(err) {
 			log.Error().Err(err).Msg("cannot update status, kubernetes client error")
 			if generationChanged {
-				sentry.CaptureError(err, sentryTags, sentry.Extras{"message": "Updating of instance status failed"})
+				sentry.CaptureErrorWithContext(ctx, err, nil, sentry.Extras{"message": "Updating of instance status failed"})
 			}
 		}
 		log.Error().Err(err).Msg("cannot update reconciliation Conditions, kubernetes client error")
@@ -292,20 +317,49 @@ func updateStatus(ctx context.Context, cl client.Client, original runtime.Object
 func (l *LifecycleManager) handleOperatorError(ctx context.Context, operatorError errors.OperatorError, msg string, generationChanged bool) (ctrl.Result, error) {
 	l.log.Error().Bool("retry", operatorError.Retry()).Bool("sentry", operatorError.Sentry()).Err(operatorError.Err()).Msg(msg)
 	if generationChanged && operatorError.Sentry() {
-		sentry.CaptureError(operatorError.Err(), sentry.GetSentryTagsFromContext(ctx))
+		captureOperatorError(ctx, operatorError)
 	}
 
 	if operatorError.Retry() {
+		if requeueAfter := requeueAfterFromOperatorError(operatorError); requeueAfter > 0 {
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
 		return ctrl.Result{}, operatorError.Err()
 	}
 
 	return ctrl.Result{}, nil
 }
 
-func (l *LifecycleManager) handleClientError(msg string, log *logger.Logger, err error, generationChanged bool, sentryTags sentry.Tags) (ctrl.Result, error) {
+// requeueAfterFromOperatorError returns the explicit requeue delay of an OperatorError, if provided
+func requeueAfterFromOperatorError(operatorError errors.OperatorError) time.Duration {
+	if detailed, ok := errors.AsDetailedOperatorError(operatorError); ok {
+		return detailed.RequeueAfter()
+	}
+	return 0
+}
+
+// captureOperatorError sends the error to Sentry including the tags of the context and the tags and extras of the OperatorError
+func captureOperatorError(ctx context.Context, operatorError errors.OperatorError) {
+	detailed, ok := errors.AsDetailedOperatorError(operatorError)
+	if !ok {
+		sentry.CaptureErrorWithContext(ctx, operatorError.Err(), nil)
+		return
+	}
+
+	tags := sentry.Tags{}
+	for k, v := range detailed.SentryTags() {
+		tags.Add(k, v)
+	}
+	if detailed.Reason() != "" {
+		tags.Add("reason", detailed.Reason())
+	}
+	sentry.CaptureErrorWithContext(ctx, operatorError.Err(), tags, detailed.SentryExtras())
+}
+
+func (l *LifecycleManager) handleClientError(ctx context.Context, msg string, log *logger.Logger, err error, generationChanged bool) (ctrl.Result, error) {
 	log.Error().Err(err).Msg(msg)
 	if generationChanged {
-		sentry.CaptureError(err, sentryTags)
+		sentry.CaptureErrorWithContext(ctx, err, nil)
 	}
 
 	return ctrl.Result{}, err
@@ -320,9 +374,10 @@ func containsFinalizer(o client.Object, subroutineFinalizers []string) bool {
 	return false
 }
 
-func (l *LifecycleManager) reconcileSubroutine(ctx context.Context, instance RuntimeObject, subroutine Subroutine, log *logger.Logger, generationChanged bool, sentryTags map[string]string) (ctrl.Result, bool, error) {
+func (l *LifecycleManager) reconcileSubroutine(ctx context.Context, instance RuntimeObject, subroutine Subroutine, log *logger.Logger, generationChanged bool) (ctrl.Result, errors.OperatorError) {
 	subroutineLogger := log.ChildLogger("subroutine", subroutine.GetName())
 	ctx = logger.SetLoggerInContext(ctx, subroutineLogger)
+	ctx = sentry.ContextWithSentryTags(ctx, sentry.Tags{"subroutine": subroutine.GetName()})
 	subroutineLogger.Debug().Msg("start subroutine")
 
 	ctx, span := otel.Tracer(l.operatorName).Start(ctx, fmt.Sprintf("%s.reconcileSubroutine.%s", l.controllerName, subroutine.GetName()))
@@ -345,16 +400,17 @@ func (l *LifecycleManager) reconcileSubroutine(ctx context.Context, instance Run
 		subroutineLogger.Debug().Any("result", result).Msg("processed instance")
 	}
 
-	if err != nil {
+	// an OperatorError without error counts as success
+	if err != nil && err.Err() != nil {
 		if generationChanged && err.Sentry() {
-			sentry.CaptureError(err.Err(), sentryTags)
+			captureOperatorError(ctx, err)
 		}
 		subroutineLogger.Error().Err(err.Err()).Bool("retry", err.Retry()).Msg("subroutine ended with error")
-		return result, err.Retry(), err.Err()
+		return result, err
 	}
 
 	subroutineLogger.Debug().Msg("end subroutine")
-	return result, false, nil
+	return result, nil
 }
 
 func (l *LifecycleManager) addFinalizersIfNeeded(ctx context.Context, instance RuntimeObject) error {
@@ -1,6 +1,7 @@
 package lifecycle
 
 import (
+	"bytes"
 	"context"
 	goerrors "errors"
 	"fmt"
@@ -17,15 +18,18 @@ import (
 	"k8s.io/client-go/rest"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	operrors "github.com/openmfp/golang-commons/errors"
 
+	"github.com/openmfp/golang-commons/controller/conditions"
 	"github.com/openmfp/golang-commons/controller/lifecycle/mocks"
 	"github.com/openmfp/golang-commons/controller/testSupport"
 	"github.com/openmfp/golang-commons/logger"
 	"github.com/openmfp/golang-commons/logger/testlogger"
 	"github.com/openmfp/golang-commons/sentry"
+	"github.com/openmfp/golang-commons/sentry/testsentry"
 )
 
 func TestLifecycle(t *testing.T) {
@@ -632,7 +636,7 @@ func TestLifecycle(t *testing.T) {
 		testErr := fmt.Errorf("test error")
 
 		// Act
-		result, err := lm.handleClientError("test", log.Logger, testErr, true, sentry.Tags{})
+		result, err := lm.handleClientError(context.Background(), "test", log.Logger, testErr, true)
 
 		// Assert
 		assert.Error(t, err)
@@ -701,6 +705,236 @@ func TestLifecycle(t *testing.T) {
 		assert.Equal(t, "The subroutine is complete", instance.Status.Conditions[1].Message)
 	})
 
+	t.Run("Lifecycle with manage conditions notifies condition transition hooks", func(t *testing.T) {
+		// Arrange
+		instance := &implementConditions{
+			testSupport.TestApiObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  namespace,
+					Generation: 1,
+				},
+				Status: testSupport.TestStatus{},
+			},
+		}
+
+		fakeClient := testSupport.CreateFakeClient(t, instance)
+
+		var transitions []conditions.Transition
+		mgr, _ := createLifecycleManager([]Subroutine{changeStatusSubroutine{
+			client: fakeClient,
+		}}, fakeClient)
+		mgr.WithConditionManagement().WithConditionTransitionHooks(func(_ context.Context, _ RuntimeObject, t []conditions.Transition) {
+			transitions = append(transitions, t...)
+		})
+
+		// Act
+		_, err := mgr.Reconcile(ctx, request, instance)
+
+		// Assert
+		assert.NoError(t, err)
+		require.Len(t, transitions, 2)
+		assert.Equal(t, ConditionReady, transitions[0].Type)
+		assert.Equal(t, metav1.ConditionStatus(""), transitions[0].OldStatus)
+		assert.Equal(t, metav1.ConditionTrue, transitions[0].NewStatus)
+		assert.Equal(t, "changeStatus_Ready", transitions[1].Type)
+		assert.Equal(t, metav1.ConditionTrue, transitions[1].NewStatus)
+	})
+
+	t.Run("Lifecycle with manage conditions does not notify condition transition hooks if the status update fails", func(t *testing.T) {
+		// Arrange
+		instance := &implementConditions{
+			testSupport.TestApiObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  namespace,
+					Generation: 1,
+				},
+				Status: testSupport.TestStatus{},
+			},
+		}
+
+		fakeClient := interceptor.NewClient(testSupport.CreateFakeClient(t, instance), interceptor.Funcs{
+			SubResourceUpdate: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+				return errors.NewInternalError(goerrors.New("status update failed"))
+			},
+		})
+
+		for _, subroutine := range []Subroutine{changeStatusSubroutine{client: fakeClient}, failureScenarioSubroutine{Retry: true}} {
+			var transitions []conditions.Transition
+			mgr, _ := createLifecycleManager([]Subroutine{subroutine}, fakeClient)
+			mgr.WithConditionManagement().WithConditionTransitionHooks(func(_ context.Context, _ RuntimeObject, t []conditions.Transition) {
+				transitions = append(transitions, t...)
+			})
+
+			// Act
+			_, err := mgr.Reconcile(ctx, request, instance)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Empty(t, transitions, subroutine.GetName())
+		}
+	})
+
+	t.Run("Lifecycle with manage conditions uses the details of an operator error", func(t *testing.T) {
+		// Arrange
+		instance := &implementConditions{
+			testSupport.TestApiObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  namespace,
+					Generation: 1,
+				},
+				Status: testSupport.TestStatus{},
+			},
+		}
+
+		fakeClient := testSupport.CreateFakeClient(t, instance)
+
+		opErr := operrors.NewOperatorError(goerrors.New("internal details"), true, true,
+			operrors.WithRequeueAfter(time.Minute),
+			operrors.WithReason("DependencyNotReady"),
+			operrors.WithUserMessage("waiting for the dependency"))
+		mgr, _ := createLifecycleManager([]Subroutine{operatorErrorSubroutine{err: opErr}}, fakeClient)
+		mgr.WithConditionManagement()
+
+		// Act
+		result, err := mgr.Reconcile(ctx, request, instance)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, result.RequeueAfter)
+		require.Len(t, instance.Status.Conditions, 2)
+		assert.Equal(t, "operatorErrorSubroutine_Ready", instance.Status.Conditions[1].Type)
+		assert.Equal(t, metav1.ConditionFalse, instance.Status.Conditions[1].Status)
+		assert.Equal(t, "DependencyNotReady", instance.Status.Conditions[1].Reason)
+		assert.Equal(t, "The subroutine has an error: waiting for the dependency", instance.Status.Conditions[1].Message)
+	})
+
+	t.Run("Lifecycle records the log events of the reconcile as breadcrumbs", func(t *testing.T) {
+		// Arrange
+		transport := testsentry.Init(t)
+		instance := &testSupport.TestApiObject{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+		fakeClient := testSupport.CreateFakeClient(t, instance)
+		log, err := logger.New(logger.Config{Level: "info", Output: &bytes.Buffer{}, Breadcrumbs: true})
+		require.NoError(t, err)
+
+		mgr := NewLifecycleManager(log, "test-operator", "test-controller", fakeClient, []Subroutine{
+			loggingSubroutine{message: "loading dependency"},
+			operatorErrorSubroutine{err: operrors.NewOperatorError(goerrors.New("dependency not ready"), false, true)},
+		})
+
+		// Act
+		_, err = mgr.Reconcile(ctx, request, instance)
+		_, _ = mgr.Reconcile(ctx, request, instance)
+
+		// Assert
+		assert.NoError(t, err)
+		events := transport.Events()
+		require.Len(t, events, 2)
+		for _, event := range events {
+			var messages []string
+			for _, breadcrumb := range event.Breadcrumbs {
+				messages = append(messages, breadcrumb.Message)
+			}
+			assert.Equal(t, []string{"start reconcile", "loading dependency"}, messages)
+		}
+	})
+
+	t.Run("Lifecycle with manage conditions treats an operator error without error as success", func(t *testing.T) {
+		// Arrange
+		instance := &implementConditions{
+			testSupport.TestApiObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  namespace,
+					Generation: 1,
+				},
+				Status: testSupport.TestStatus{},
+			},
+		}
+
+		fakeClient := testSupport.CreateFakeClient(t, instance)
+
+		mgr, _ := createLifecycleManager([]Subroutine{
+			operatorErrorSubroutine{err: operrors.NewOperatorError(nil, true, true)},
+			changeStatusSubroutine{client: fakeClient},
+		}, fakeClient)
+		mgr.WithConditionManagement()
+
+		// Act
+		result, err := mgr.Reconcile(ctx, request, instance)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, controllerruntime.Result{}, result)
+		assert.Equal(t, "other string", instance.Status.Some)
+		require.Len(t, instance.Status.Conditions, 3)
+		assert.Equal(t, ConditionReady, instance.Status.Conditions[0].Type)
+		assert.Equal(t, metav1.ConditionTrue, instance.Status.Conditions[0].Status)
+		assert.Equal(t, "operatorErrorSubroutine_Ready", instance.Status.Conditions[1].Type)
+		assert.Equal(t, metav1.ConditionTrue, instance.Status.Conditions[1].Status)
+		assert.Equal(t, "The subroutine is complete", instance.Status.Conditions[1].Message)
+	})
+
+	t.Run("Lifecycle with manage conditions notifies condition transition hooks on error", func(t *testing.T) {
+		// Arrange
+		instance := &implementConditions{
+			testSupport.TestApiObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  namespace,
+					Generation: 1,
+				},
+				Status: testSupport.TestStatus{
+					Conditions: []metav1.Condition{
+						{Type: ConditionReady, Status: metav1.ConditionTrue, Reason: reasonComplete},
+						{Type: "failureScenarioSubroutine_Ready", Status: metav1.ConditionTrue, Reason: reasonComplete},
+					},
+				},
+			},
+		}
+
+		fakeClient := testSupport.CreateFakeClient(t, instance)
+
+		var transitions []conditions.Transition
+		mgr, log := createLifecycleManager([]Subroutine{failureScenarioSubroutine{Retry: true}}, fakeClient)
+		mgr.WithConditionManagement().WithConditionTransitionHooks(func(_ context.Context, _ RuntimeObject, t []conditions.Transition) {
+			transitions = append(transitions, t...)
+		})
+
+		// Act
+		_, err := mgr.Reconcile(ctx, request, instance)
+
+		// Assert
+		assert.Error(t, err)
+		require.Len(t, transitions, 2)
+		assert.Equal(t, ConditionReady, transitions[0].Type)
+		assert.Equal(t, metav1.ConditionTrue, transitions[0].OldStatus)
+		assert.Equal(t, metav1.ConditionFalse, transitions[0].NewStatus)
+		assert.Equal(t, "failureScenarioSubroutine_Ready", transitions[1].Type)
+		assert.Equal(t, reasonError, transitions[1].Reason)
+
+		logMessages, err := log.GetLogMessages()
+		assert.NoError(t, err)
+		var updated []testlogger.LogMessage
+		for _, msg := range logMessages {
+			if msg.Message == "updated condition" {
+				updated = append(updated, msg)
+			}
+		}
+		require.Len(t, updated, 1)
+		assert.Equal(t, "failureScenarioSubroutine_Ready", updated[0].Attributes["type"])
+		assert.Equal(t, string(metav1.ConditionTrue), updated[0].Attributes["old_status"])
+		assert.Equal(t, string(metav1.ConditionFalse), updated[0].Attributes["new_status"])
+		assert.Equal(t, reasonError, updated[0].Attributes["reason"])
+	})
+
 	t.Run("Lifecycle with manage conditions reconciles with subroutine that adds a condition", func(t *testing.T) {
 		// Arrange
 		instance := &implementConditions{
@@ -1282,6 +1516,45 @@ func TestLifecycle(t *testing.T) {
 			assert.Equal(t, errorMessage, *errorMessages[0].Error)
 		})
 
+		t.Run("Should requeue an operator error with an explicit delay", func(t *testing.T) {
+			// Arrange
+			instance := &implementConditions{}
+			fakeClient := testSupport.CreateFakeClient(t, instance)
+
+			lm, _ := createLifecycleManager([]Subroutine{}, fakeClient)
+			ctx = sentry.ContextWithSentryTags(ctx, map[string]string{})
+			opErr := operrors.NewOperatorError(goerrors.New(errorMessage), true, true,
+				operrors.WithRequeueAfter(time.Minute), operrors.WithReason("Throttled"), operrors.WithSentryTags(map[string]string{"key": "value"}))
+
+			// Act
+			result, err := lm.handleOperatorError(ctx, opErr, "handle op error", true)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, time.Minute, result.RequeueAfter)
+		})
+
+		t.Run("Should send an operator error with its tags to sentry", func(t *testing.T) {
+			// Arrange
+			transport := testsentry.Init(t)
+			instance := &implementConditions{}
+			fakeClient := testSupport.CreateFakeClient(t, instance)
+
+			lm, _ := createLifecycleManager([]Subroutine{}, fakeClient)
+			ctx := sentry.ContextWithSentryTags(ctx, map[string]string{"name": "test"})
+			opErr := operrors.NewOperatorError(goerrors.New(errorMessage), false, true, operrors.WithSentryTags(map[string]string{"key": "value"}))
+
+			// Act
+			_, _ = lm.handleOperatorError(ctx, opErr, "handle op error", true)
+			_, _ = lm.handleOperatorError(ctx, opErr, "handle op error", false)
+
+			// Assert
+			transport.AssertEventCount(t, 1)
+			transport.AssertException(t, errorMessage)
+			transport.AssertTag(t, "name", "test")
+			transport.AssertTag(t, "key", "value")
+		})
+
 		t.Run("Should handle an operator error without retry", func(t *testing.T) {
 			// Arrange
 			instance := &implementConditions{}
@@ -1365,7 +1638,7 @@ func TestUpdateStatus(t *testing.T) {
 			}}
 
 		// When
-		err := updateStatus(context.Background(), clientMock, original, original, log, true, nil)
+		err := updateStatus(context.Background(), clientMock, original, original, log, true)
 
 		// Then
 		assert.NoError(t, err)
@@ -1390,7 +1663,7 @@ func TestUpdateStatus(t *testing.T) {
 			Return(errors.NewBadRequest("internal error"))
 
 		// When
-		err := updateStatus(context.Background(), clientMock, original, current, log, true, nil)
+		err := updateStatus(context.Background(), clientMock, original, current, log, true)
 
 		// Then
 		assert.Error(t, err)
@@ -1401,7 +1674,7 @@ func TestUpdateStatus(t *testing.T) {
 		original := &testSupport.TestNoStatusApiObject{}
 		current := &implementConditions{}
 		// When
-		err := updateStatus(context.Background(), clientMock, original, current, log, true, nil)
+		err := updateStatus(context.Background(), clientMock, original, current, log, true)
 
 		// Then
 		assert.Error(t, err)
@@ -1411,7 +1684,7 @@ func TestUpdateStatus(t *testing.T) {
 		original := &implementConditions{}
 		current := &testSupport.TestNoStatusApiObject{}
 		// When
-		err := updateStatus(context.Background(), clientMock, original, current, log, true, nil)
+		err := updateStatus(context.Background(), clientMock, original, current, log, true)
 
 		// Then
 		assert.Error(t, err)
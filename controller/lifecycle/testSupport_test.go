@@ -16,6 +16,7 @@ import (
 
 	"github.com/openmfp/golang-commons/controller/testSupport"
 	"github.com/openmfp/golang-commons/errors"
+	"github.com/openmfp/golang-commons/logger"
 )
 
 const failureScenarioSubroutineFinalizer = "failuresubroutine"
@@ -231,3 +232,44 @@ func (f contextValueSubroutine) Finalizers() []string {
 func (c contextValueSubroutine) GetName() string {
 	return "contextValueSubroutine"
 }
+
+type operatorErrorSubroutine struct {
+	err errors.OperatorError
+}
+
+func (o operatorErrorSubroutine) Process(_ context.Context, _ RuntimeObject) (controllerruntime.Result, errors.OperatorError) {
+	return controllerruntime.Result{}, o.err
+}
+
+func (o operatorErrorSubroutine) Finalize(_ context.Context, _ RuntimeObject) (controllerruntime.Result, errors.OperatorError) {
+	return controllerruntime.Result{}, o.err
+}
+
+func (o operatorErrorSubroutine) Finalizers() []string {
+	return []string{}
+}
+
+func (o operatorErrorSubroutine) GetName() string {
+	return "operatorErrorSubroutine"
+}
+
+type loggingSubroutine struct {
+	message string
+}
+
+func (l loggingSubroutine) Process(ctx context.Context, _ RuntimeObject) (controllerruntime.Result, errors.OperatorError) {
+	logger.LoadLoggerFromContext(ctx).Info().Msg(l.message)
+	return controllerruntime.Result{}, nil
+}
+
+func (l loggingSubroutine) Finalize(_ context.Context, _ RuntimeObject) (controllerruntime.Result, errors.OperatorError) {
+	return controllerruntime.Result{}, nil
+}
+
+func (l loggingSubroutine) Finalizers() []string {
+	return []string{}
+}
+
+func (l loggingSubroutine) GetName() string {
+	return "loggingSubroutine"
+}
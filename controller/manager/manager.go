@@ -0,0 +1,182 @@
+package manager
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/openmfp/golang-commons/config"
+	"github.com/openmfp/golang-commons/config/sentryconfig"
+	"github.com/openmfp/golang-commons/controller/lifecycle"
+	"github.com/openmfp/golang-commons/logger"
+	"github.com/openmfp/golang-commons/traces"
+)
+
+const (
+	healthzCheckName = "healthz"
+	readyzCheckName  = "readyz"
+)
+
+// Manager wraps a controller-runtime manager which was configured from a CommonServiceConfig
+type Manager struct {
+	ctrl.Manager
+	cfg              *config.CommonServiceConfig
+	log              *logger.Logger
+	shutdownTracing  func(ctx context.Context) error
+	restConfig       *rest.Config
+	managerOptionFns []func(*ctrl.Options)
+}
+
+// Option allows to adjust the manager before it is created
+type Option func(*Manager)
+
+// WithScheme sets the scheme used by the manager
+func WithScheme(scheme *runtime.Scheme) Option {
+	return WithManagerOptions(func(o *ctrl.Options) {
+		o.Scheme = scheme
+	})
+}
+
+// WithLeaderElectionID overwrites the default leader election id `<name>.openmfp.io`
+func WithLeaderElectionID(id string) Option {
+	return WithManagerOptions(func(o *ctrl.Options) {
+		o.LeaderElectionID = id
+	})
+}
+
+// WithRestConfig sets the rest config instead of loading it from the configured kubeconfig
+func WithRestConfig(restConfig *rest.Config) Option {
+	return func(m *Manager) {
+		m.restConfig = restConfig
+	}
+}
+
+// WithManagerOptions allows to adjust the controller-runtime options after they were derived from the config
+func WithManagerOptions(fn func(*ctrl.Options)) Option {
+	return func(m *Manager) {
+		m.managerOptionFns = append(m.managerOptionFns, fn)
+	}
+}
+
+// New creates a manager from the given config. It starts tracing and Sentry and installs the health and readiness checks.
+// The tracing provider is shut down once Start returns.
+func New(ctx context.Context, name string, cfg *config.CommonServiceConfig, log *logger.Logger, opts ...Option) (*Manager, error) {
+	m := &Manager{
+		cfg: cfg,
+		log: log.ComponentLogger("manager"),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	restConfig, err := m.loadRestConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	if err := sentryconfig.Start(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("failed to start sentry: %w", err)
+	}
+
+	if cfg.Tracing.Enabled {
+		m.shutdownTracing, err = traces.InitProvider(ctx, cfg.Tracing.Collector)
+	} else {
+		m.shutdownTracing, err = traces.InitLocalProvider(ctx, cfg.Tracing.Collector, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start tracing provider: %w", err)
+	}
+
+	options := managerOptions(name, cfg)
+	for _, fn := range m.managerOptionFns {
+		fn(&options)
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, options)
+	if err != nil {
+		return nil, m.shutdownAfterError(ctx, fmt.Errorf("failed to create manager: %w", err))
+	}
+	m.Manager = mgr
+
+	if err := mgr.AddHealthzCheck(healthzCheckName, healthz.Ping); err != nil {
+		return nil, m.shutdownAfterError(ctx, fmt.Errorf("failed to set up health check: %w", err))
+	}
+	if err := mgr.AddReadyzCheck(readyzCheckName, healthz.Ping); err != nil {
+		return nil, m.shutdownAfterError(ctx, fmt.Errorf("failed to set up ready check: %w", err))
+	}
+
+	return m, nil
+}
+
+// shutdownAfterError shuts down the tracing provider if New fails after starting it and returns the error
+func (m *Manager) shutdownAfterError(ctx context.Context, err error) error {
+	if shutdownErr := m.shutdownTracing(ctx); shutdownErr != nil {
+		m.log.Error().Err(shutdownErr).Msg("failed to shut down tracing provider")
+	}
+	return err
+}
+
+func managerOptions(name string, cfg *config.CommonServiceConfig) ctrl.Options {
+	var tlsOpts []func(*tls.Config)
+	if !cfg.EnableHTTP2 {
+		// Disabling http/2 prevents being vulnerable to the HTTP/2 Stream Cancellation and Rapid Reset CVEs
+		tlsOpts = append(tlsOpts, func(c *tls.Config) {
+			c.NextProtos = []string{"http/1.1"}
+		})
+	}
+
+	shutdownTimeout := cfg.ShutdownTimeout
+	return ctrl.Options{
+		Metrics: metricsserver.Options{
+			BindAddress:   cfg.Metrics.BindAddress,
+			SecureServing: cfg.Metrics.Secure,
+			TLSOpts:       tlsOpts,
+		},
+		WebhookServer:           webhook.NewServer(webhook.Options{TLSOpts: tlsOpts}),
+		HealthProbeBindAddress:  cfg.HealthProbeBindAddress,
+		LeaderElection:          cfg.LeaderElection.Enabled,
+		LeaderElectionID:        fmt.Sprintf("%s.openmfp.io", name),
+		GracefulShutdownTimeout: &shutdownTimeout,
+	}
+}
+
+func (m *Manager) loadRestConfig() (*rest.Config, error) {
+	if m.restConfig != nil {
+		return m.restConfig, nil
+	}
+	if m.cfg.Kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", m.cfg.Kubeconfig)
+	}
+	return ctrl.GetConfig()
+}
+
+// RegisterLifecycleController registers a controller using the LifecycleManager. The debug label value and
+// max concurrent reconciles are taken from the config.
+func (m *Manager) RegisterLifecycleController(reconcilerName string, lm *lifecycle.LifecycleManager, instance lifecycle.RuntimeObject, r reconcile.Reconciler, eventPredicates ...predicate.Predicate) error {
+	m.log.Debug().Str("reconciler", reconcilerName).Msg("registering controller")
+	return lm.SetupWithManager(m.Manager, m.cfg.MaxConcurrentReconciles, reconcilerName, instance, m.cfg.DebugLabelValue, r, m.log, eventPredicates...)
+}
+
+// Start starts the manager and blocks until the context is done. Afterwards the tracing provider is shut down.
+func (m *Manager) Start(ctx context.Context) error {
+	m.log.Info().Msg("starting manager")
+	err := m.Manager.Start(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.cfg.ShutdownTimeout)
+	defer cancel()
+	if shutdownErr := m.shutdownTracing(shutdownCtx); shutdownErr != nil {
+		m.log.Error().Err(shutdownErr).Msg("failed to shut down tracing provider")
+	}
+
+	return err
+}
@@ -0,0 +1,134 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openmfp/golang-commons/config"
+	"github.com/openmfp/golang-commons/controller/lifecycle"
+	"github.com/openmfp/golang-commons/controller/testSupport"
+	"github.com/openmfp/golang-commons/logger/testlogger"
+)
+
+type testReconciler struct{}
+
+func (r *testReconciler) Reconcile(_ context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, nil
+}
+
+func testConfig() *config.CommonServiceConfig {
+	cfg := &config.CommonServiceConfig{}
+	cfg.MaxConcurrentReconciles = 3
+	cfg.DebugLabelValue = "test"
+	cfg.Metrics.BindAddress = "0"
+	cfg.HealthProbeBindAddress = "0"
+	cfg.ShutdownTimeout = time.Second
+	cfg.Sentry.SampleRate = 1.0
+	return cfg
+}
+
+func TestNew(t *testing.T) {
+	t.Run("creates a manager from the config", func(t *testing.T) {
+		// Arrange
+		cfg := testConfig()
+		cfg.EnableHTTP2 = false
+		log := testlogger.New()
+		fakeClient := testSupport.CreateFakeClient(t, &testSupport.TestApiObject{})
+		var leaderElectionID string
+
+		// Act
+		mgr, err := New(context.Background(), "test-operator", cfg, log.Logger,
+			WithRestConfig(&rest.Config{}), WithScheme(fakeClient.Scheme()), WithLeaderElectionID("custom-id"),
+			WithManagerOptions(func(o *ctrl.Options) { leaderElectionID = o.LeaderElectionID }))
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, mgr.Manager)
+		assert.Equal(t, fakeClient.Scheme(), mgr.GetScheme())
+		assert.Equal(t, "custom-id", leaderElectionID)
+	})
+
+	t.Run("shuts down tracing if the manager can not be created", func(t *testing.T) {
+		// Arrange
+		cfg := testConfig()
+		log := testlogger.New()
+		restConfig := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAFile: "/does/not/exist"}}
+
+		// Act
+		_, err := New(context.Background(), "test-operator", cfg, log.Logger, WithRestConfig(restConfig))
+
+		// Assert
+		assert.ErrorContains(t, err, "failed to create manager")
+		_, span := otel.Tracer("test").Start(context.Background(), "test")
+		assert.False(t, span.IsRecording())
+	})
+
+	t.Run("fails with a missing kubeconfig file", func(t *testing.T) {
+		// Arrange
+		cfg := testConfig()
+		cfg.Kubeconfig = "/does/not/exist"
+		log := testlogger.New()
+
+		// Act
+		_, err := New(context.Background(), "test-operator", cfg, log.Logger)
+
+		// Assert
+		assert.ErrorContains(t, err, "failed to load kubeconfig")
+	})
+}
+
+func TestManagerOptions(t *testing.T) {
+	cfg := testConfig()
+	cfg.LeaderElection.Enabled = true
+	cfg.Metrics.Secure = true
+	cfg.EnableHTTP2 = false
+
+	options := managerOptions("test-operator", cfg)
+
+	assert.True(t, options.LeaderElection)
+	assert.Equal(t, "test-operator.openmfp.io", options.LeaderElectionID)
+	assert.True(t, options.Metrics.SecureServing)
+	assert.Len(t, options.Metrics.TLSOpts, 1)
+	assert.Equal(t, time.Second, *options.GracefulShutdownTimeout)
+}
+
+func TestRegisterLifecycleController(t *testing.T) {
+	// Arrange
+	log := testlogger.New()
+	fakeClient := testSupport.CreateFakeClient(t, &testSupport.TestApiObject{})
+	mgr, err := New(context.Background(), "test-operator", testConfig(), log.Logger,
+		WithRestConfig(&rest.Config{}), WithScheme(fakeClient.Scheme()))
+	require.NoError(t, err)
+	lm := lifecycle.NewLifecycleManager(log.Logger, "test-operator", "test-controller", fakeClient, []lifecycle.Subroutine{})
+
+	// Act
+	err = mgr.RegisterLifecycleController("testReconciler", lm, &testSupport.TestApiObject{}, reconcile.Reconciler(&testReconciler{}))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestStart(t *testing.T) {
+	// Arrange
+	log := testlogger.New()
+	fakeClient := testSupport.CreateFakeClient(t, &testSupport.TestApiObject{})
+	mgr, err := New(context.Background(), "test-operator", testConfig(), log.Logger,
+		WithRestConfig(&rest.Config{}), WithScheme(fakeClient.Scheme()))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err = mgr.Start(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -142,12 +142,13 @@ func Authorized(openfgaClient openfgav1.OpenFGAServiceClient, log *logger.Logger
 
 		res, err := openfgaClient.Check(ctx, req)
 		if err != nil {
-			log.Error().Err(err).Str("user", req.TupleKey.User).Msg("authorization check failed")
+			log.Error().Err(err).Stringer("user", logger.Sensitive(req.TupleKey.User)).Msg("authorization check failed")
 			return nil, err
 		}
 
 		if !res.Allowed {
-			log.Warn().Bool("allowed", res.Allowed).Any("req", req).Msg("not allowed")
+			log.Warn().Bool("allowed", res.Allowed).Str("relation", relation).Str("object", req.TupleKey.Object).
+				Stringer("user", logger.Sensitive(req.TupleKey.User)).Msg("not allowed")
 			return nil, gqlerror.Errorf("unauthorized")
 		}
 
@@ -0,0 +1,257 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Classification groups error codes by their meaning for API clients
+type Classification string
+
+const (
+	ClassificationInvalidArgument    Classification = "INVALID_ARGUMENT"
+	ClassificationUnauthenticated    Classification = "UNAUTHENTICATED"
+	ClassificationPermissionDenied   Classification = "PERMISSION_DENIED"
+	ClassificationNotFound           Classification = "NOT_FOUND"
+	ClassificationAlreadyExists      Classification = "ALREADY_EXISTS"
+	ClassificationConflict           Classification = "CONFLICT"
+	ClassificationFailedPrecondition Classification = "FAILED_PRECONDITION"
+	ClassificationResourceExhausted  Classification = "RESOURCE_EXHAUSTED"
+	ClassificationUnimplemented      Classification = "UNIMPLEMENTED"
+	ClassificationUnavailable        Classification = "UNAVAILABLE"
+	ClassificationTimeout            Classification = "TIMEOUT"
+	ClassificationInternal           Classification = "INTERNAL"
+)
+
+// ErrorInfoDomain is used as domain of the ErrorInfo detail added to gRPC statuses
+const ErrorInfoDomain = "openmfp.io"
+
+const (
+	// GraphQLCodeExtension is the key of the gqlerror extension containing the error code
+	GraphQLCodeExtension = "code"
+	// GraphQLClassificationExtension is the key of the gqlerror extension containing the classification
+	GraphQLClassificationExtension = "classification"
+)
+
+var classificationMappings = map[Classification]struct {
+	httpStatus int
+	grpcCode   codes.Code
+}{
+	ClassificationInvalidArgument:    {http.StatusBadRequest, codes.InvalidArgument},
+	ClassificationUnauthenticated:    {http.StatusUnauthorized, codes.Unauthenticated},
+	ClassificationPermissionDenied:   {http.StatusForbidden, codes.PermissionDenied},
+	ClassificationNotFound:           {http.StatusNotFound, codes.NotFound},
+	ClassificationAlreadyExists:      {http.StatusConflict, codes.AlreadyExists},
+	ClassificationConflict:           {http.StatusConflict, codes.Aborted},
+	ClassificationFailedPrecondition: {http.StatusBadRequest, codes.FailedPrecondition},
+	ClassificationResourceExhausted:  {http.StatusTooManyRequests, codes.ResourceExhausted},
+	ClassificationUnimplemented:      {http.StatusNotImplemented, codes.Unimplemented},
+	ClassificationUnavailable:        {http.StatusServiceUnavailable, codes.Unavailable},
+	ClassificationTimeout:            {http.StatusGatewayTimeout, codes.DeadlineExceeded},
+	ClassificationInternal:           {http.StatusInternalServerError, codes.Internal},
+}
+
+// HTTPStatus returns the HTTP status code of the classification, unknown classifications result in 500
+func (c Classification) HTTPStatus() int {
+	if mapping, ok := classificationMappings[c]; ok {
+		return mapping.httpStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// GRPCCode returns the gRPC status code of the classification, unknown classifications result in codes.Unknown
+func (c Classification) GRPCCode() codes.Code {
+	if mapping, ok := classificationMappings[c]; ok {
+		return mapping.grpcCode
+	}
+	return codes.Unknown
+}
+
+// Code is a stable, machine-readable error code with a default message
+type Code struct {
+	Code           string
+	Message        string
+	Classification Classification
+}
+
+var (
+	codeRegistryMu sync.RWMutex
+	codeRegistry   = map[string]Code{}
+)
+
+// Generic codes which can be used if no more specific code is registered
+var (
+	CodeInternal         = RegisterCode("INTERNAL", "internal error", ClassificationInternal)
+	CodeInvalidArgument  = RegisterCode("INVALID_ARGUMENT", "invalid argument", ClassificationInvalidArgument)
+	CodeNotFound         = RegisterCode("NOT_FOUND", "not found", ClassificationNotFound)
+	CodeUnauthenticated  = RegisterCode("UNAUTHENTICATED", "unauthenticated", ClassificationUnauthenticated)
+	CodePermissionDenied = RegisterCode("PERMISSION_DENIED", "permission denied", ClassificationPermissionDenied)
+)
+
+// RegisterCode adds a code to the registry and returns it. It panics if the code is empty or already registered,
+// so codes should be registered in package level variables.
+func RegisterCode(code string, message string, classification Classification) Code {
+	if code == "" {
+		panic("errors: error code must not be empty")
+	}
+
+	codeRegistryMu.Lock()
+	defer codeRegistryMu.Unlock()
+	if _, ok := codeRegistry[code]; ok {
+		panic(fmt.Sprintf("errors: error code %q is already registered", code))
+	}
+
+	c := Code{Code: code, Message: message, Classification: classification}
+	codeRegistry[code] = c
+	return c
+}
+
+// LookupCode returns the registered code
+func LookupCode(code string) (Code, bool) {
+	codeRegistryMu.RLock()
+	defer codeRegistryMu.RUnlock()
+	c, ok := codeRegistry[code]
+	return c, ok
+}
+
+// RegisteredCodes returns all registered codes sorted by code
+func RegisteredCodes() []Code {
+	codeRegistryMu.RLock()
+	defer codeRegistryMu.RUnlock()
+	result := make([]Code, 0, len(codeRegistry))
+	for _, c := range codeRegistry {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result
+}
+
+type codedError struct {
+	error
+	code Code
+}
+
+func (e *codedError) Unwrap() error {
+	return e.error
+}
+
+func (e *codedError) Cause() error {
+	return e.error
+}
+
+// Format keeps the stack traces of the wrapped error when formatted with %+v
+func (e *codedError) Format(s fmt.State, verb rune) {
+	if formatter, ok := e.error.(fmt.Formatter); ok {
+		formatter.Format(s, verb)
+		return
+	}
+	_, _ = fmt.Fprint(s, e.Error())
+}
+
+// GRPCStatus allows gRPC to convert the error into a status when it is returned from a handler
+func (e *codedError) GRPCStatus() *status.Status {
+	return codeStatus(e.code, e.Error())
+}
+
+// WithCode attaches a code to an error without changing its message
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{error: err, code: code}
+}
+
+// NewWithCode creates a stack traced error with a code. The default message of the code is used if msg is empty.
+func NewWithCode(code Code, msg string, args ...interface{}) error {
+	if msg == "" {
+		msg, args = "%s", []interface{}{code.Message}
+	}
+	return WithCode(PopStack(New(msg, args...)), code)
+}
+
+// WrapWithCode wraps the cause like Wrap and attaches a code. The default message of the code is used if msg is empty.
+func WrapWithCode(cause error, code Code, msg string, args ...interface{}) error {
+	if cause == nil {
+		return nil
+	}
+	if msg == "" {
+		msg, args = "%s", []interface{}{code.Message}
+	}
+	return WithCode(Wrap(cause, msg, args...), code)
+}
+
+// CodeOf returns the outermost code attached to the error or one of its wrapped errors
+func CodeOf(err error) (Code, bool) {
+	var coded *codedError
+	if !As(err, &coded) {
+		return Code{}, false
+	}
+	return coded.code, true
+}
+
+// HTTPStatus returns the HTTP status code for an error, errors without code result in 500
+func HTTPStatus(err error) int {
+	code, ok := CodeOf(err)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	return code.Classification.HTTPStatus()
+}
+
+// GRPCStatus converts an error into a gRPC status. Coded errors carry the code as ErrorInfo detail,
+// errors which already provide a gRPC status keep it and all other errors result in codes.Unknown.
+func GRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	if code, ok := CodeOf(err); ok {
+		return codeStatus(code, err.Error())
+	}
+	st, _ := status.FromError(err)
+	return st
+}
+
+func codeStatus(code Code, msg string) *status.Status {
+	st := status.New(code.Classification.GRPCCode(), msg)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: code.Code, Domain: ErrorInfoDomain})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// GraphQLExtensions returns the gqlerror extensions for the code of an error, it returns nil for errors without code
+func GraphQLExtensions(err error) map[string]interface{} {
+	code, ok := CodeOf(err)
+	if !ok {
+		return nil
+	}
+	return map[string]interface{}{
+		GraphQLCodeExtension:           code.Code,
+		GraphQLClassificationExtension: string(code.Classification),
+	}
+}
+
+// AddGraphQLExtensions adds the code of an error to the extensions of the gqlerror
+func AddGraphQLExtensions(gqlErr *gqlerror.Error, err error) *gqlerror.Error {
+	extensions := GraphQLExtensions(err)
+	if gqlErr == nil || extensions == nil {
+		return gqlErr
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	for k, v := range extensions {
+		if _, ok := gqlErr.Extensions[k]; !ok {
+			gqlErr.Extensions[k] = v
+		}
+	}
+	return gqlErr
+}
@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var codeTestQuotaExceeded = RegisterCode("TEST_QUOTA_EXCEEDED", "quota exceeded", ClassificationResourceExhausted)
+
+func TestRegisterCode(t *testing.T) {
+	code, ok := LookupCode("TEST_QUOTA_EXCEEDED")
+	assert.True(t, ok)
+	assert.Equal(t, codeTestQuotaExceeded, code)
+	assert.Contains(t, RegisteredCodes(), CodeInternal)
+
+	assert.Panics(t, func() { RegisterCode("TEST_QUOTA_EXCEEDED", "duplicate", ClassificationInternal) })
+	assert.Panics(t, func() { RegisterCode("", "empty", ClassificationInternal) })
+}
+
+func TestNewWithCode(t *testing.T) {
+	err := NewWithCode(codeTestQuotaExceeded, "")
+	assert.Equal(t, "quota exceeded", err.Error())
+	assert.Len(t, getStackTraces(err), 1)
+
+	err = NewWithCode(codeTestQuotaExceeded, "quota of %s exceeded", "tenant")
+	assert.Equal(t, "quota of tenant exceeded", err.Error())
+
+	code, ok := CodeOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, codeTestQuotaExceeded, code)
+}
+
+func TestWrapWithCode(t *testing.T) {
+	cause := fmt.Errorf("cause")
+	err := WrapWithCode(cause, CodeNotFound, "")
+	assert.Equal(t, "not found: cause", err.Error())
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, cause, Cause(err))
+	assert.Len(t, getStackTraces(err), 1)
+
+	wrapped := fmt.Errorf("outer: %w", err)
+	code, ok := CodeOf(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, CodeNotFound, code)
+
+	assert.Nil(t, WrapWithCode(nil, CodeNotFound, ""))
+	assert.Nil(t, WithCode(nil, CodeNotFound))
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusTooManyRequests, HTTPStatus(WithCode(fmt.Errorf("oops"), codeTestQuotaExceeded)))
+	assert.Equal(t, http.StatusNotFound, HTTPStatus(WithCode(fmt.Errorf("oops"), CodeNotFound)))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatus(fmt.Errorf("oops")))
+	assert.Equal(t, http.StatusInternalServerError, Classification("UNKNOWN").HTTPStatus())
+}
+
+func TestGRPCStatus(t *testing.T) {
+	t.Run("Coded error", func(t *testing.T) {
+		err := WithCode(fmt.Errorf("oops"), CodePermissionDenied)
+
+		st := GRPCStatus(err)
+		assert.Equal(t, codes.PermissionDenied, st.Code())
+		assert.Equal(t, "oops", st.Message())
+		require.Len(t, st.Details(), 1)
+		info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		assert.Equal(t, "PERMISSION_DENIED", info.Reason)
+		assert.Equal(t, ErrorInfoDomain, info.Domain)
+
+		fromError, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.PermissionDenied, fromError.Code())
+	})
+
+	t.Run("Existing status", func(t *testing.T) {
+		st := GRPCStatus(status.Error(codes.Unavailable, "unavailable"))
+		assert.Equal(t, codes.Unavailable, st.Code())
+	})
+
+	t.Run("Error without code", func(t *testing.T) {
+		assert.Equal(t, codes.Unknown, GRPCStatus(fmt.Errorf("oops")).Code())
+		assert.Equal(t, codes.OK, GRPCStatus(nil).Code())
+	})
+}
+
+func TestAddGraphQLExtensions(t *testing.T) {
+	err := WithCode(fmt.Errorf("oops"), CodeInvalidArgument)
+
+	gqlErr := AddGraphQLExtensions(gqlerror.Wrap(err), err)
+	assert.Equal(t, map[string]interface{}{
+		GraphQLCodeExtension:           "INVALID_ARGUMENT",
+		GraphQLClassificationExtension: "INVALID_ARGUMENT",
+	}, gqlErr.Extensions)
+
+	existing := &gqlerror.Error{Message: "oops", Extensions: map[string]interface{}{GraphQLCodeExtension: "CUSTOM"}}
+	gqlErr = AddGraphQLExtensions(existing, err)
+	assert.Equal(t, "CUSTOM", gqlErr.Extensions[GraphQLCodeExtension])
+
+	plain := gqlerror.Wrap(fmt.Errorf("oops"))
+	assert.Nil(t, AddGraphQLExtensions(plain, fmt.Errorf("oops")).Extensions)
+}
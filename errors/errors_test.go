@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -125,6 +126,30 @@ func TestOperatorErrorIsNil(t *testing.T) {
 	err := oe.Err()
 
 	assert.Nil(t, err)
+	assert.Zero(t, oe.RequeueAfter())
+	assert.Empty(t, oe.Reason())
+	assert.Empty(t, oe.UserMessage())
+	assert.Nil(t, oe.SentryTags())
+	assert.Nil(t, oe.SentryExtras())
+}
+
+func TestNewOperatorErrorWithDetails(t *testing.T) {
+	err := NewOperatorError(New("oops"), true, true,
+		WithRequeueAfter(time.Minute),
+		WithReason("QuotaExceeded"),
+		WithUserMessage("quota exceeded"),
+		WithSentryTags(map[string]string{"key": "value"}),
+		WithSentryExtras(map[string]interface{}{"extra": 1}),
+	)
+
+	detailed, ok := AsDetailedOperatorError(err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, detailed.RequeueAfter())
+	assert.Equal(t, "QuotaExceeded", detailed.Reason())
+	assert.Equal(t, "quota exceeded", detailed.UserMessage())
+	assert.Equal(t, "oops", detailed.Err().Error())
+	assert.Equal(t, map[string]string{"key": "value"}, detailed.SentryTags())
+	assert.Equal(t, map[string]interface{}{"extra": 1}, detailed.SentryExtras())
 }
 
 func TestPopStackNil(t *testing.T) {
@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ReasonMultipleErrors is used as reason of aggregated OperatorErrors with different reasons
+const ReasonMultipleErrors = "MultipleErrors"
+
+// MultiError aggregates several errors, e.g. collected while processing many items. It works with Is and As and
+// keeps the wrapped errors untouched, so the stack trace of each cause stays available.
+type MultiError struct {
+	errs []error
+}
+
+// Join aggregates the errors into a MultiError and skips nil errors. It returns nil if no error is left.
+// Nested MultiErrors are flattened.
+func Join(errs ...error) error {
+	return Append(nil, errs...)
+}
+
+// Append adds errors to err. If err is a MultiError the errors are added to it, otherwise a new MultiError is created.
+func Append(err error, errs ...error) error {
+	multiErr := &MultiError{}
+	multiErr.append(err)
+	for _, e := range errs {
+		multiErr.append(e)
+	}
+	if len(multiErr.errs) == 0 {
+		return nil
+	}
+	return multiErr
+}
+
+func (e *MultiError) append(err error) {
+	if err == nil {
+		return
+	}
+	if multiErr, ok := err.(*MultiError); ok {
+		e.errs = append(e.errs, multiErr.errs...)
+		return
+	}
+	e.errs = append(e.errs, err)
+}
+
+// Errors returns the aggregated errors
+func (e *MultiError) Errors() []error {
+	return e.errs
+}
+
+// Unwrap returns the aggregated errors so Is and As check each of them
+func (e *MultiError) Unwrap() []error {
+	return e.errs
+}
+
+func (e *MultiError) Error() string {
+	if len(e.errs) == 1 {
+		return e.errs[0].Error()
+	}
+	messages := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(e.errs), strings.Join(messages, "; "))
+}
+
+// Format prints the stack trace of each aggregated error when formatted with %+v
+func (e *MultiError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		_, _ = fmt.Fprintf(s, "%d errors occurred:", len(e.errs))
+		for i, err := range e.errs {
+			_, _ = fmt.Fprintf(s, "\n[%d] %+v", i, err)
+		}
+		return
+	}
+	_, _ = io.WriteString(s, e.Error())
+}
+
+// AggregateOperatorErrors combines several OperatorErrors into one, nil errors are skipped.
+// The result is retried if any error should be retried and sent to Sentry if any error should be sent.
+// The shortest requeue delay of the retried errors is used and tags, extras and user messages are merged.
+func AggregateOperatorErrors(opErrs ...OperatorError) OperatorError {
+	aggregated := &operatorError{}
+	var errs []error
+	reasons := map[string]struct{}{}
+	var userMessages []string
+	for _, opErr := range opErrs {
+		if opErr == nil || opErr.Err() == nil {
+			continue
+		}
+		errs = append(errs, opErr.Err())
+		aggregated.retry = aggregated.retry || opErr.Retry()
+		aggregated.sentry = aggregated.sentry || opErr.Sentry()
+
+		detailed, ok := AsDetailedOperatorError(opErr)
+		if !ok {
+			continue
+		}
+		if opErr.Retry() {
+			aggregated.requeueAfter = shortestRequeueAfter(aggregated.requeueAfter, detailed.RequeueAfter())
+		}
+		if detailed.Reason() != "" {
+			reasons[detailed.Reason()] = struct{}{}
+		}
+		if detailed.UserMessage() != "" {
+			userMessages = append(userMessages, detailed.UserMessage())
+		}
+		if len(detailed.SentryTags()) > 0 {
+			WithSentryTags(detailed.SentryTags())(aggregated)
+		}
+		if len(detailed.SentryExtras()) > 0 {
+			WithSentryExtras(detailed.SentryExtras())(aggregated)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	aggregated.err = Join(errs...)
+	aggregated.userMessage = strings.Join(userMessages, "; ")
+	switch len(reasons) {
+	case 0:
+	case 1:
+		for reason := range reasons {
+			aggregated.reason = reason
+		}
+	default:
+		aggregated.reason = ReasonMultipleErrors
+	}
+	return aggregated
+}
+
+func shortestRequeueAfter(current, next time.Duration) time.Duration {
+	if next > 0 && (current == 0 || next < current) {
+		return next
+	}
+	return current
+}
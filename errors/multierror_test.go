@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type customError struct{}
+
+func (customError) Error() string {
+	return "custom"
+}
+
+func TestJoin(t *testing.T) {
+	first := New("first")
+	second := fmt.Errorf("second: %w", customError{})
+
+	err := Join(first, nil, second)
+
+	assert.Equal(t, "2 errors occurred: first; second: custom", err.Error())
+	assert.ErrorIs(t, err, first)
+	var custom customError
+	assert.True(t, As(err, &custom))
+
+	var multiErr *MultiError
+	assert.True(t, As(err, &multiErr))
+	assert.Equal(t, []error{first, second}, multiErr.Errors())
+	assert.Len(t, multiErr.Unwrap(), 2)
+	assert.Len(t, getStackTraces(multiErr.Errors()[0]), 1)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "errors.TestJoin")
+}
+
+func TestJoinSingleAndNil(t *testing.T) {
+	assert.Nil(t, Join())
+	assert.Nil(t, Join(nil, nil))
+	assert.Equal(t, "only", Join(errors.New("only")).Error())
+}
+
+func TestAppend(t *testing.T) {
+	var err error
+	err = Append(err, errors.New("first"))
+	err = Append(err, errors.New("second"), Join(errors.New("third"), errors.New("fourth")))
+
+	var multiErr *MultiError
+	assert.True(t, As(err, &multiErr))
+	assert.Len(t, multiErr.Errors(), 4)
+}
+
+func TestAggregateOperatorErrors(t *testing.T) {
+	t.Run("Retries and reports if any error requires it", func(t *testing.T) {
+		err := AggregateOperatorErrors(
+			NewOperatorError(New("first"), false, true, WithReason("Invalid"), WithUserMessage("invalid")),
+			nil,
+			NewOperatorError(New("second"), true, false, WithRequeueAfter(time.Minute), WithReason("Invalid"),
+				WithSentryTags(map[string]string{"key": "value"})),
+			NewOperatorError(New("third"), true, false, WithRequeueAfter(time.Second), WithUserMessage("throttled")),
+		)
+
+		assert.True(t, err.Retry())
+		assert.True(t, err.Sentry())
+		assert.Equal(t, "3 errors occurred: first; second; third", err.Err().Error())
+		detailed, ok := AsDetailedOperatorError(err)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, detailed.RequeueAfter())
+		assert.Equal(t, "Invalid", detailed.Reason())
+		assert.Equal(t, "invalid; throttled", detailed.UserMessage())
+		assert.Equal(t, map[string]string{"key": "value"}, detailed.SentryTags())
+		assert.Nil(t, detailed.SentryExtras())
+	})
+
+	t.Run("Uses a generic reason for different reasons", func(t *testing.T) {
+		err := AggregateOperatorErrors(
+			NewOperatorError(New("first"), false, false, WithReason("Invalid")),
+			NewOperatorError(New("second"), false, false, WithReason("Conflict")),
+		)
+
+		assert.False(t, err.Retry())
+		assert.False(t, err.Sentry())
+		detailed, _ := AsDetailedOperatorError(err)
+		assert.Equal(t, ReasonMultipleErrors, detailed.Reason())
+	})
+
+	t.Run("Returns nil without errors", func(t *testing.T) {
+		assert.Nil(t, AggregateOperatorErrors(nil, NewOperatorError(nil, true, true)))
+	})
+}
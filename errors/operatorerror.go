@@ -1,9 +1,16 @@
 package errors
 
+import "time"
+
 type operatorError struct {
-	err    error
-	retry  bool
-	sentry bool
+	err          error
+	retry        bool
+	sentry       bool
+	requeueAfter time.Duration
+	reason       string
+	userMessage  string
+	sentryTags   map[string]string
+	sentryExtras map[string]interface{}
 }
 
 func (e *operatorError) Err() error {
@@ -21,12 +28,119 @@ func (e *operatorError) Sentry() bool {
 	return e != nil && e.err != nil && e.sentry
 }
 
+func (e *operatorError) RequeueAfter() time.Duration {
+	if e == nil {
+		return 0
+	}
+	return e.requeueAfter
+}
+
+func (e *operatorError) Reason() string {
+	if e == nil {
+		return ""
+	}
+	return e.reason
+}
+
+func (e *operatorError) UserMessage() string {
+	if e == nil {
+		return ""
+	}
+	return e.userMessage
+}
+
+func (e *operatorError) SentryTags() map[string]string {
+	if e == nil {
+		return nil
+	}
+	return e.sentryTags
+}
+
+func (e *operatorError) SentryExtras() map[string]interface{} {
+	if e == nil {
+		return nil
+	}
+	return e.sentryExtras
+}
+
 type OperatorError interface {
 	Err() error
 	Retry() bool
 	Sentry() bool
 }
 
-func NewOperatorError(err error, retry bool, sentry bool) OperatorError {
-	return &operatorError{err: err, retry: retry, sentry: sentry}
+// DetailedOperatorError is an OperatorError carrying additional details about how the error should be handled.
+// It is kept separate from OperatorError so existing implementations stay compatible.
+type DetailedOperatorError interface {
+	OperatorError
+	// RequeueAfter is the delay before the next retry, zero means the default rate limiting applies
+	RequeueAfter() time.Duration
+	// Reason is a machine-readable reason code, e.g. used as condition reason
+	Reason() string
+	// UserMessage is a sanitized message which can be shown to users instead of the internal error
+	UserMessage() string
+	SentryTags() map[string]string
+	SentryExtras() map[string]interface{}
+}
+
+// OperatorErrorOption allows to add details to an OperatorError
+type OperatorErrorOption func(*operatorError)
+
+// WithRequeueAfter sets the delay before the next retry
+func WithRequeueAfter(requeueAfter time.Duration) OperatorErrorOption {
+	return func(e *operatorError) {
+		e.requeueAfter = requeueAfter
+	}
+}
+
+// WithReason sets a machine-readable reason code
+func WithReason(reason string) OperatorErrorOption {
+	return func(e *operatorError) {
+		e.reason = reason
+	}
+}
+
+// WithUserMessage sets a sanitized user-facing message
+func WithUserMessage(message string) OperatorErrorOption {
+	return func(e *operatorError) {
+		e.userMessage = message
+	}
+}
+
+// WithSentryTags adds tags which are sent to Sentry
+func WithSentryTags(tags map[string]string) OperatorErrorOption {
+	return func(e *operatorError) {
+		if e.sentryTags == nil {
+			e.sentryTags = map[string]string{}
+		}
+		for k, v := range tags {
+			e.sentryTags[k] = v
+		}
+	}
+}
+
+// WithSentryExtras adds extras which are sent to Sentry
+func WithSentryExtras(extras map[string]interface{}) OperatorErrorOption {
+	return func(e *operatorError) {
+		if e.sentryExtras == nil {
+			e.sentryExtras = map[string]interface{}{}
+		}
+		for k, v := range extras {
+			e.sentryExtras[k] = v
+		}
+	}
+}
+
+func NewOperatorError(err error, retry bool, sentry bool, opts ...OperatorErrorOption) OperatorError {
+	e := &operatorError{err: err, retry: retry, sentry: sentry}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// AsDetailedOperatorError returns the details of an OperatorError if it provides them
+func AsDetailedOperatorError(err OperatorError) (DetailedOperatorError, bool) {
+	detailed, ok := err.(DetailedOperatorError)
+	return detailed, ok
 }
@@ -0,0 +1,78 @@
+// Package httputil contains HTTP helpers shared by the middlewares of this module
+package httputil
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ResponseRecorder records the status code and size of a response
+type ResponseRecorder struct {
+	http.ResponseWriter
+	status      int
+	size        int
+	wroteHeader bool
+}
+
+// NewResponseRecorder wraps the response writer
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: w}
+}
+
+func (w *ResponseRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *ResponseRecorder) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+// StatusCode returns the written status code, http.StatusOK if none was written yet
+func (w *ResponseRecorder) StatusCode() int {
+	if !w.wroteHeader {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Size returns the number of body bytes written
+func (w *ResponseRecorder) Size() int {
+	return w.size
+}
+
+// WroteHeader returns true if the status code was written
+func (w *ResponseRecorder) WroteHeader() bool {
+	return w.wroteHeader
+}
+
+// Flush supports streaming responses, e.g. GraphQL subscriptions using server-sent events
+func (w *ResponseRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack supports websocket connections, e.g. GraphQL subscriptions
+func (w *ResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer of type %T does not support hijacking", w.ResponseWriter)
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap allows http.ResponseController to access the original response writer
+func (w *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
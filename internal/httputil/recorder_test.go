@@ -0,0 +1,29 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseRecorder(t *testing.T) {
+	rec := httptest.NewRecorder()
+	recorder := NewResponseRecorder(rec)
+
+	assert.Equal(t, http.StatusOK, recorder.StatusCode())
+	assert.False(t, recorder.WroteHeader())
+	_, err := recorder.Write([]byte("ok"))
+	assert.NoError(t, err)
+	recorder.WriteHeader(http.StatusInternalServerError)
+	recorder.Flush()
+
+	assert.Equal(t, http.StatusOK, recorder.StatusCode())
+	assert.True(t, recorder.WroteHeader())
+	assert.Equal(t, 2, recorder.Size())
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, rec, recorder.Unwrap())
+	_, _, err = recorder.Hijack()
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+// Package redact matches keys whose values are redacted by the logger and the Sentry scrubber
+package redact
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+)
+
+// Keys matches keys which end with one of its keys or their plural at a segment boundary. Segments are split on '_', '-',
+// '.', spaces and camelCase, so clientSecret, x-api-key and API_KEY match secret and api_key, but secretName and
+// tokenizer do not.
+type Keys struct {
+	keys []string
+	// lastSegments are contained in every matching key and allow a quick check of unparsed data
+	lastSegments [][]byte
+}
+
+// NewKeys creates Keys matching the given keys
+func NewKeys(keys ...string) *Keys {
+	k := &Keys{}
+	k.Add(keys...)
+	return k
+}
+
+// Add adds keys to match
+func (k *Keys) Add(keys ...string) {
+	for _, key := range keys {
+		segments := segments(key)
+		if len(segments) == 0 {
+			continue
+		}
+		k.keys = append(k.keys, strings.Join(segments, ""))
+		k.lastSegments = append(k.lastSegments, []byte(segments[len(segments)-1]))
+	}
+}
+
+// Match checks if the key ends with one of the keys
+func (k *Keys) Match(key string) bool {
+	segments := segments(key)
+	for i := range segments {
+		suffix := strings.Join(segments[i:], "")
+		for _, matched := range k.keys {
+			if suffix == matched || suffix == matched+"s" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MayContain checks if the lower case data may contain a matching key
+func (k *Keys) MayContain(lower []byte) bool {
+	for _, segment := range k.lastSegments {
+		if bytes.Contains(lower, segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// segments splits the key into lower case segments
+func segments(key string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(key)
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// boundaries of camelCase and of acronyms followed by a word, e.g. apiKey and APIKey
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return result
+}
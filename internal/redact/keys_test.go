@@ -0,0 +1,33 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysMatch(t *testing.T) {
+	keys := NewKeys("secret", "token", "api_key", "password")
+
+	for _, key := range []string{"secret", "clientSecret", "client_secret", "X-Api-Key", "apiKey", "APIKey", "API_KEY", "apikey", "accessToken", "Password", "passwords"} {
+		assert.True(t, keys.Match(key), key)
+	}
+	for _, key := range []string{"secretName", "secretRef", "tokenizer", "keyName", "api", "", "passwordHash"} {
+		assert.False(t, keys.Match(key), key)
+	}
+}
+
+func TestKeysMayContain(t *testing.T) {
+	keys := NewKeys("api_key")
+
+	assert.True(t, keys.MayContain([]byte(`{"x-api-key":"value"}`)))
+	assert.False(t, keys.MayContain([]byte(`{"name":"value"}`)))
+}
+
+func TestSegments(t *testing.T) {
+	assert.Equal(t, []string{"client", "secret", "ref"}, segments("clientSecretRef"))
+	assert.Equal(t, []string{"api", "key"}, segments("APIKey"))
+	assert.Equal(t, []string{"x", "api", "key"}, segments("x-api_key"))
+	assert.Equal(t, []string{"v2", "token"}, segments("v2Token"))
+	assert.Empty(t, segments("--"))
+}
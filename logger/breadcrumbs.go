@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultBreadcrumbBufferSize is the number of breadcrumbs kept per hub if no buffer size is configured
+	DefaultBreadcrumbBufferSize = 100
+
+	breadcrumbCategory = "log"
+)
+
+var breadcrumbLevels = map[zerolog.Level]sentry.Level{
+	zerolog.TraceLevel: sentry.LevelDebug,
+	zerolog.DebugLevel: sentry.LevelDebug,
+	zerolog.InfoLevel:  sentry.LevelInfo,
+	zerolog.WarnLevel:  sentry.LevelWarning,
+	zerolog.ErrorLevel: sentry.LevelError,
+	zerolog.FatalLevel: sentry.LevelFatal,
+	zerolog.PanicLevel: sentry.LevelFatal,
+}
+
+// BreadcrumbHook is a zerolog hook which records log events as Sentry breadcrumbs on the hub of the event context.
+// Events without context or without a hub in their context are ignored, use Ctx(), LoadLoggerFromContext or
+// NewRequestLoggerFromZerolog to add the context. Hooks only run for logged events, so events dropped by the level or
+// sampling are not recorded.
+type BreadcrumbHook struct {
+	level      zerolog.Level
+	bufferSize int
+}
+
+// NewBreadcrumbHook creates a hook recording events with at least the given level
+func NewBreadcrumbHook(level zerolog.Level, bufferSize int) *BreadcrumbHook {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBreadcrumbBufferSize
+	}
+	return &BreadcrumbHook{level: level, bufferSize: bufferSize}
+}
+
+// Run implements zerolog.Hook
+func (h *BreadcrumbHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	if level < h.level || level == zerolog.NoLevel || level == zerolog.Disabled {
+		return
+	}
+	hub := sentry.GetHubFromContext(e.GetCtx())
+	if hub == nil {
+		return
+	}
+
+	hub.Scope().AddBreadcrumb(&sentry.Breadcrumb{
+		Type:      "default",
+		Category:  breadcrumbCategory,
+		Message:   msg,
+		Level:     breadcrumbLevels[level],
+		Timestamp: time.Now(),
+	}, h.bufferSize)
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub(t *testing.T) (*sentry.Hub, *sentry.MockTransport) {
+	transport := &sentry.MockTransport{}
+	client, err := sentry.NewClient(sentry.ClientOptions{Transport: transport})
+	require.NoError(t, err)
+	return sentry.NewHub(client, sentry.NewScope()), transport
+}
+
+func capturedBreadcrumbs(hub *sentry.Hub, transport *sentry.MockTransport) []*sentry.Breadcrumb {
+	hub.CaptureMessage("test")
+	events := transport.Events()
+	return events[len(events)-1].Breadcrumbs
+}
+
+func TestBreadcrumbHook(t *testing.T) {
+	t.Run("Record events of the context hub above the level", func(t *testing.T) {
+		hub, transport := newTestHub(t)
+		ctx := sentry.SetHubOnContext(context.Background(), hub)
+		log := zerolog.New(&bytes.Buffer{}).Hook(NewBreadcrumbHook(zerolog.InfoLevel, 0))
+
+		log.Debug().Ctx(ctx).Msg("debug")
+		log.Info().Ctx(ctx).Msg("info")
+		log.Error().Ctx(ctx).Msg("error")
+		log.Info().Msg("without context")
+
+		breadcrumbs := capturedBreadcrumbs(hub, transport)
+		require.Len(t, breadcrumbs, 2)
+		assert.Equal(t, "info", breadcrumbs[0].Message)
+		assert.Equal(t, sentry.LevelInfo, breadcrumbs[0].Level)
+		assert.Equal(t, "log", breadcrumbs[0].Category)
+		assert.Equal(t, "error", breadcrumbs[1].Message)
+		assert.Equal(t, sentry.LevelError, breadcrumbs[1].Level)
+	})
+
+	t.Run("Limit breadcrumbs to the buffer size", func(t *testing.T) {
+		hub, transport := newTestHub(t)
+		ctx := sentry.SetHubOnContext(context.Background(), hub)
+		log := zerolog.New(&bytes.Buffer{}).Hook(NewBreadcrumbHook(zerolog.DebugLevel, 2)).With().Ctx(ctx).Logger()
+
+		log.Info().Msg("first")
+		log.Info().Msg("second")
+		log.Info().Msg("third")
+
+		breadcrumbs := capturedBreadcrumbs(hub, transport)
+		require.Len(t, breadcrumbs, 2)
+		assert.Equal(t, "second", breadcrumbs[0].Message)
+	})
+}
+
+func TestNewWithBreadcrumbs(t *testing.T) {
+	hub, transport := newTestHub(t)
+	ctx := sentry.SetHubOnContext(context.Background(), hub)
+	cfg := DefaultConfig()
+	cfg.Output = &bytes.Buffer{}
+	cfg.Level = "debug"
+	cfg.Breadcrumbs = true
+	cfg.BreadcrumbLevel = "warn"
+	log, err := New(cfg)
+	require.NoError(t, err)
+
+	requestLogger := NewRequestLoggerFromZerolog(ctx, log.Logger)
+	requestLogger.Info().Msg("info")
+	requestLogger.ComponentLogger("test").Warn().Msg("warn")
+
+	breadcrumbs := capturedBreadcrumbs(hub, transport)
+	require.Len(t, breadcrumbs, 1)
+	assert.Equal(t, "warn", breadcrumbs[0].Message)
+}
+
+func TestNewWithInvalidBreadcrumbLevel(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Breadcrumbs = true
+	cfg.BreadcrumbLevel = "invalid"
+
+	_, err := New(cfg)
+
+	assert.Error(t, err)
+}
+
+func TestNewWithBreadcrumbLevelBelowLevel(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Level = "info"
+	cfg.Breadcrumbs = true
+	cfg.BreadcrumbLevel = "debug"
+
+	_, err := New(cfg)
+
+	assert.ErrorContains(t, err, "below the log level")
+}
+
+func TestLoadLoggerFromContextWithHub(t *testing.T) {
+	hub, transport := newTestHub(t)
+	cfg := DefaultConfig()
+	cfg.Output = &bytes.Buffer{}
+	cfg.Level = "debug"
+	cfg.Breadcrumbs = true
+	log, err := New(cfg)
+	require.NoError(t, err)
+	// the logger is stored before the hub is added, like by a middleware running before the Sentry middleware
+	ctx := SetLoggerInContext(context.Background(), log)
+	ctx = sentry.SetHubOnContext(ctx, hub)
+
+	LoadLoggerFromContext(ctx).Debug().Msg("debug")
+
+	breadcrumbs := capturedBreadcrumbs(hub, transport)
+	require.Len(t, breadcrumbs, 1)
+	assert.Equal(t, "debug", breadcrumbs[0].Message)
+}
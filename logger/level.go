@@ -0,0 +1,265 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zerologr"
+	"github.com/rs/zerolog"
+)
+
+// DefaultDebugTimeout is the time after which a level toggled by SIGUSR1 is reverted if no other timeout is given
+const DefaultDebugTimeout = 10 * time.Minute
+
+// componentFieldName is the field added by ComponentLogger, its value selects the component level
+const componentFieldName = "component"
+
+// LevelController holds the level of all loggers created by New and their child loggers, so it can be changed at
+// runtime. Component levels overwrite the level for loggers created by ComponentLogger.
+type LevelController struct {
+	mu         sync.RWMutex
+	level      zerolog.Level
+	components map[string]zerolog.Level
+
+	// debugToggled is set by ToggleDebug, debugTimer reverts the level to levelBeforeDebug
+	debugToggled     bool
+	debugTimer       *time.Timer
+	levelBeforeDebug zerolog.Level
+}
+
+// NewLevelController creates a LevelController with the given level and without component levels
+func NewLevelController(level zerolog.Level) *LevelController {
+	return &LevelController{
+		level:      level,
+		components: map[string]zerolog.Level{},
+	}
+}
+
+// Level returns the level of loggers without component level
+func (c *LevelController) Level() zerolog.Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.level
+}
+
+// SetLevel changes the level of loggers without component level and cancels a pending revert of ToggleDebug
+func (c *LevelController) SetLevel(level zerolog.Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.endDebugToggle()
+	c.level = level
+}
+
+// ComponentLevels returns a copy of the component levels
+func (c *LevelController) ComponentLevels() map[string]zerolog.Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make(map[string]zerolog.Level, len(c.components))
+	for component, level := range c.components {
+		result[component] = level
+	}
+	return result
+}
+
+// SetComponentLevel overwrites the level for loggers of the component
+func (c *LevelController) SetComponentLevel(component string, level zerolog.Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.components[component] = level
+}
+
+// RemoveComponentLevel lets loggers of the component follow the level again
+func (c *LevelController) RemoveComponentLevel(component string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.components, component)
+}
+
+// Enabled returns true if events of the level are logged by loggers of the component
+func (c *LevelController) Enabled(component string, level zerolog.Level) bool {
+	return level >= c.componentLevel(component)
+}
+
+// componentLevel returns the level of the component, or the level if the component has none
+func (c *LevelController) componentLevel(component string) zerolog.Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if componentLevel, ok := c.components[component]; ok && component != "" {
+		return componentLevel
+	}
+	return c.level
+}
+
+// ToggleDebug switches the level to debug and reverts it after the timeout, a timeout of zero keeps debug enabled.
+// If debug was enabled by a previous toggle, the previous level is restored immediately.
+// It returns true if debug was enabled.
+func (c *LevelController) ToggleDebug(timeout time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.debugToggled {
+		c.endDebugToggle()
+		c.level = c.levelBeforeDebug
+		return false
+	}
+
+	c.debugToggled = true
+	c.levelBeforeDebug = c.level
+	c.level = zerolog.DebugLevel
+	if timeout > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(timeout, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			// ignore the timer if the toggle was ended in the meantime
+			if c.debugTimer == timer {
+				c.endDebugToggle()
+				c.level = c.levelBeforeDebug
+			}
+		})
+		c.debugTimer = timer
+	}
+	return true
+}
+
+func (c *LevelController) endDebugToggle() {
+	if c.debugTimer != nil {
+		c.debugTimer.Stop()
+		c.debugTimer = nil
+	}
+	c.debugToggled = false
+}
+
+// ParseComponentLevels parses a comma separated list of component levels, e.g. "lifecycle=debug,graphql=warn"
+func ParseComponentLevels(value string) (map[string]zerolog.Level, error) {
+	result := map[string]zerolog.Level{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		component, levelName, ok := strings.Cut(entry, "=")
+		component = strings.TrimSpace(component)
+		levelName = strings.TrimSpace(levelName)
+		if !ok || component == "" || levelName == "" {
+			return nil, fmt.Errorf("invalid component level %q, expected component=level", entry)
+		}
+		level, err := zerolog.ParseLevel(strings.ToLower(levelName))
+		if err != nil {
+			return nil, fmt.Errorf("invalid level of component %q: %w", component, err)
+		}
+		result[component] = level
+	}
+	return result, nil
+}
+
+// levelStatus is returned by the HTTP handler
+type levelStatus struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components,omitempty"`
+}
+
+// levelRequest changes the level or the level of a component, an empty level removes the component level
+type levelRequest struct {
+	Level     string `json:"level"`
+	Component string `json:"component,omitempty"`
+}
+
+// ServeHTTP returns the levels on GET and changes them on PUT, e.g. with {"level": "debug"} or
+// {"component": "graphql", "level": "warn"}. An empty level removes the level of the component.
+func (c *LevelController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := c.update(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut}, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := levelStatus{Level: c.Level().String(), Components: map[string]string{}}
+	for component, level := range c.ComponentLevels() {
+		status.Components[component] = level.String()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+func (c *LevelController) update(r *http.Request) error {
+	var req levelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	if req.Component != "" && req.Level == "" {
+		c.RemoveComponentLevel(req.Component)
+		return nil
+	}
+	level, err := zerolog.ParseLevel(strings.ToLower(req.Level))
+	if err != nil || req.Level == "" {
+		return fmt.Errorf("invalid level %q", req.Level)
+	}
+	if req.Component != "" {
+		c.SetComponentLevel(req.Component, level)
+		return nil
+	}
+	c.SetLevel(level)
+	return nil
+}
+
+// levelSampler lets zerolog drop events below the level of the component before they are created.
+// Events passing the level are sampled by the configured sampler, if any.
+type levelSampler struct {
+	levels    *LevelController
+	component string
+	sampler   zerolog.Sampler
+}
+
+// Sample implements zerolog.Sampler
+func (s levelSampler) Sample(level zerolog.Level) bool {
+	if s.levels != nil && !s.levels.Enabled(s.component, level) {
+		return false
+	}
+	return s.sampler == nil || s.sampler.Sample(level)
+}
+
+// levelLogSink is a logr.LogSink which checks the LevelController in Enabled, so logr callers like controller-runtime
+// skip building messages of disabled V-levels
+type levelLogSink struct {
+	*zerologr.LogSink
+	sampler levelSampler
+}
+
+// Enabled implements logr.LogSink
+func (s levelLogSink) Enabled(level int) bool {
+	zerologLevel := zerolog.Level(1 - level)
+	return zerologLevel >= zerolog.GlobalLevel() && s.sampler.levels.Enabled(s.sampler.component, zerologLevel)
+}
+
+// WithValues implements logr.LogSink
+func (s levelLogSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return s.wrap(s.LogSink.WithValues(keysAndValues...))
+}
+
+// WithName implements logr.LogSink
+func (s levelLogSink) WithName(name string) logr.LogSink {
+	return s.wrap(s.LogSink.WithName(name))
+}
+
+// WithCallDepth implements logr.CallDepthLogSink
+func (s levelLogSink) WithCallDepth(depth int) logr.LogSink {
+	return s.wrap(s.LogSink.WithCallDepth(depth))
+}
+
+func (s levelLogSink) wrap(sink logr.LogSink) logr.LogSink {
+	return levelLogSink{LogSink: sink.(*zerologr.LogSink), sampler: s.sampler}
+}
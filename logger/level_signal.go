@@ -0,0 +1,29 @@
+//go:build !windows
+
+package logger
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ToggleDebugOnSignal calls ToggleDebug each time the process receives SIGUSR1 until the context is done.
+// The level is reverted after the timeout or on the next signal.
+func (c *LevelController) ToggleDebugOnSignal(ctx context.Context, timeout time.Duration) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ch:
+				c.ToggleDebug(timeout)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package logger
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleDebugOnSignal(t *testing.T) {
+	levels := NewLevelController(zerolog.InfoLevel)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	levels.ToggleDebugOnSignal(ctx, time.Minute)
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+
+	assert.Eventually(t, func() bool { return levels.Level() == zerolog.DebugLevel }, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package logger
+
+import (
+	"context"
+	"time"
+)
+
+// ToggleDebugOnSignal does nothing on Windows, as SIGUSR1 does not exist there. Use ServeHTTP to change the level.
+func (c *LevelController) ToggleDebugOnSignal(_ context.Context, _ time.Duration) {}
@@ -0,0 +1,223 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelController(t *testing.T) {
+	t.Run("Change the level of loggers and child loggers", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+		child := log.ChildLogger("key", "value")
+
+		child.Debug().Msg("before")
+		log.LevelController().SetLevel(zerolog.DebugLevel)
+		child.Debug().Msg("after")
+		log.Debug().Msg("parent")
+
+		assert.NotContains(t, buf.String(), "before")
+		assert.Contains(t, buf.String(), "after")
+		assert.Contains(t, buf.String(), "parent")
+	})
+
+	t.Run("Report the level of the controller", func(t *testing.T) {
+		log, err := New(Config{Level: "info", Output: &bytes.Buffer{}})
+		require.NoError(t, err)
+		log.LevelController().SetComponentLevel("lifecycle", zerolog.DebugLevel)
+		component := log.ComponentLogger("lifecycle")
+
+		assert.Equal(t, zerolog.InfoLevel, log.GetLevel())
+		assert.Equal(t, zerolog.DebugLevel, component.GetLevel())
+		assert.Equal(t, zerolog.WarnLevel, log.Level(Level(zerolog.WarnLevel)).GetLevel())
+
+		log.LevelController().SetLevel(zerolog.ErrorLevel)
+		assert.Equal(t, zerolog.ErrorLevel, log.GetLevel())
+		assert.Equal(t, zerolog.DebugLevel, component.GetLevel())
+	})
+
+	t.Run("Enable logr V-levels according to the controller", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+		logrLog := log.Logr().WithName("test").WithValues("key", "value")
+
+		assert.True(t, logrLog.V(0).Enabled())
+		assert.False(t, logrLog.V(1).Enabled())
+		logrLog.V(1).Info("before")
+
+		log.LevelController().SetLevel(zerolog.DebugLevel)
+		assert.True(t, logrLog.V(1).Enabled())
+		assert.False(t, logrLog.V(2).Enabled())
+		logrLog.V(1).Info("after")
+
+		assert.NotContains(t, buf.String(), "before")
+		line := logLine(t, buf)
+		assert.Equal(t, "after", line["message"])
+		assert.Equal(t, "value", line["key"])
+		assert.Contains(t, line["caller"], "level_test.go")
+	})
+
+	t.Run("Overwrite the level of a component", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+		levels := log.LevelController()
+		levels.SetComponentLevel("graphql", zerolog.WarnLevel)
+		levels.SetComponentLevel("lifecycle", zerolog.DebugLevel)
+
+		log.ComponentLogger("graphql").Info().Msg("graphql info")
+		log.ComponentLogger("lifecycle").ChildLogger("key", "value").Debug().Msg("lifecycle debug")
+		log.ComponentLogger("other").Info().Msg("other info")
+
+		assert.NotContains(t, buf.String(), "graphql info")
+		assert.Contains(t, buf.String(), "lifecycle debug")
+		assert.Contains(t, buf.String(), "other info")
+
+		levels.RemoveComponentLevel("graphql")
+		log.ComponentLogger("graphql").Info().Msg("graphql removed")
+		assert.Contains(t, buf.String(), "graphql removed")
+	})
+
+	t.Run("Share a controller between loggers", func(t *testing.T) {
+		levels := NewLevelController(zerolog.ErrorLevel)
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "debug", Output: buf, LevelController: levels})
+		require.NoError(t, err)
+
+		log.Info().Msg("info")
+
+		assert.Same(t, levels, log.LevelController())
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("Keep a fixed level", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+
+		log.Level(Level(zerolog.DebugLevel)).Debug().Msg("fixed")
+
+		assert.Contains(t, buf.String(), "fixed")
+	})
+}
+
+func TestToggleDebug(t *testing.T) {
+	t.Run("Revert after the timeout", func(t *testing.T) {
+		levels := NewLevelController(zerolog.InfoLevel)
+
+		assert.True(t, levels.ToggleDebug(10*time.Millisecond))
+		assert.Equal(t, zerolog.DebugLevel, levels.Level())
+		assert.Eventually(t, func() bool { return levels.Level() == zerolog.InfoLevel }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Revert on the next toggle", func(t *testing.T) {
+		levels := NewLevelController(zerolog.WarnLevel)
+
+		assert.True(t, levels.ToggleDebug(0))
+		assert.False(t, levels.ToggleDebug(0))
+		assert.Equal(t, zerolog.WarnLevel, levels.Level())
+	})
+
+	t.Run("Keep a level set while debug is toggled", func(t *testing.T) {
+		levels := NewLevelController(zerolog.InfoLevel)
+
+		levels.ToggleDebug(10 * time.Millisecond)
+		levels.SetLevel(zerolog.ErrorLevel)
+		time.Sleep(30 * time.Millisecond)
+
+		assert.Equal(t, zerolog.ErrorLevel, levels.Level())
+	})
+}
+
+func TestLevelControllerHTTPHandler(t *testing.T) {
+	levels := NewLevelController(zerolog.InfoLevel)
+
+	serve := func(method, body string) (*httptest.ResponseRecorder, levelStatus) {
+		rec := httptest.NewRecorder()
+		levels.ServeHTTP(rec, httptest.NewRequest(method, "/loglevel", strings.NewReader(body)))
+		status := levelStatus{}
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		}
+		return rec, status
+	}
+
+	t.Run("Get the levels", func(t *testing.T) {
+		rec, status := serve(http.MethodGet, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "info", status.Level)
+	})
+
+	t.Run("Put the level", func(t *testing.T) {
+		rec, status := serve(http.MethodPut, `{"level": "DEBUG"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "debug", status.Level)
+		assert.Equal(t, zerolog.DebugLevel, levels.Level())
+	})
+
+	t.Run("Put and remove the level of a component", func(t *testing.T) {
+		_, status := serve(http.MethodPut, `{"component": "graphql", "level": "warn"}`)
+		assert.Equal(t, map[string]string{"graphql": "warn"}, status.Components)
+
+		_, status = serve(http.MethodPut, `{"component": "graphql"}`)
+		assert.Empty(t, status.Components)
+	})
+
+	t.Run("Reject invalid levels", func(t *testing.T) {
+		rec, _ := serve(http.MethodPut, `{"level": "verbose"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		rec, _ = serve(http.MethodPut, `{}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		rec, _ = serve(http.MethodPut, `not json`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Reject other methods", func(t *testing.T) {
+		rec, _ := serve(http.MethodPost, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, PUT", rec.Header().Get("Allow"))
+	})
+}
+
+func TestParseComponentLevels(t *testing.T) {
+	levels, err := ParseComponentLevels(" lifecycle=debug, graphql = WARN,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]zerolog.Level{"lifecycle": zerolog.DebugLevel, "graphql": zerolog.WarnLevel}, levels)
+
+	levels, err = ParseComponentLevels("")
+	assert.NoError(t, err)
+	assert.Empty(t, levels)
+
+	for _, value := range []string{"lifecycle", "=debug", "lifecycle=", "lifecycle=verbose"} {
+		_, err = ParseComponentLevels(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestNewWithComponentLevels(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log, err := New(Config{Level: "info", Output: buf, ComponentLevels: "lifecycle=debug,graphql=warn"})
+	require.NoError(t, err)
+
+	log.ComponentLogger("lifecycle").Debug().Msg("lifecycle debug")
+	log.ChildLogger("component", "graphql").Info().Msg("graphql info")
+	log.Debug().Msg("root debug")
+
+	assert.Contains(t, buf.String(), "lifecycle debug")
+	assert.NotContains(t, buf.String(), "graphql info")
+	assert.NotContains(t, buf.String(), "root debug")
+
+	_, err = New(Config{Level: "info", ComponentLevels: "lifecycle"})
+	assert.Error(t, err)
+}
@@ -3,12 +3,15 @@ package logger
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"runtime"
 	"strings"
 	"time"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zerologr"
 	"github.com/rs/zerolog"
@@ -18,7 +21,10 @@ import (
 
 type Level zerolog.Level
 
-const RequestIdLoggerKey = "rid"
+const (
+	RequestIdLoggerKey   = "rid"
+	ReconcileIdLoggerKey = "reconcile_id"
+)
 
 // StdLogger is a global default logger, please use with care and prefer creating your own instance
 var StdLogger, _ = New(DefaultConfig())
@@ -29,6 +35,41 @@ type Config struct {
 	Level  string
 	NoJSON bool
 	Output io.Writer
+	// Stack logs the stack trace of errors added with Err() as structured frames
+	Stack bool
+	// StackDepth limits the number of logged frames, DefaultStackDepth is used if not set
+	StackDepth int
+	// Breadcrumbs records log events as Sentry breadcrumbs on the hub of the event context
+	Breadcrumbs bool
+	// BreadcrumbLevel is the minimal level recorded as breadcrumb, default is the log level. It must not be below the
+	// log level, as only logged events are recorded.
+	BreadcrumbLevel string
+	// BreadcrumbBufferSize is the maximum number of breadcrumbs kept per hub, DefaultBreadcrumbBufferSize is used if not set
+	BreadcrumbBufferSize int
+	// Trace adds the trace and span ID of the active OpenTelemetry span of the event context
+	Trace bool
+	// TraceIDFieldName is the field containing the trace ID, DefaultTraceIDFieldName is used if not set
+	TraceIDFieldName string
+	// SpanIDFieldName is the field containing the span ID, DefaultSpanIDFieldName is used if not set
+	SpanIDFieldName string
+	// ComponentLevels overwrites the level of component loggers, e.g. "lifecycle=debug,graphql=warn"
+	ComponentLevels string
+	// LevelController shares the levels with other loggers, Level and ComponentLevels are ignored if set.
+	// A new one is created if not set.
+	LevelController *LevelController
+	// SampleEvery logs only every n-th event below error level, values below two disable it
+	SampleEvery int
+	// SampleBurst logs the first events below error level of each SamplePeriod before SampleEvery applies,
+	// further events are dropped if SampleEvery is not set. Zero disables it.
+	SampleBurst int
+	// SamplePeriod is the period of SampleBurst, DefaultSamplePeriod is used if not set
+	SamplePeriod time.Duration
+	// RedactedKeys adds keys to DefaultRedactedKeys whose values are replaced by RedactedValue
+	RedactedKeys []string
+	// RedactionPatterns adds patterns to DefaultRedactionPatterns whose matches are replaced by RedactedValue
+	RedactionPatterns []*regexp.Regexp
+	// NoRedaction turns off the redaction of keys and patterns, sensitive values are still redacted
+	NoRedaction bool
 }
 
 // SetDefaults set config default values
@@ -58,6 +99,12 @@ func DefaultConfig() Config {
 // Logger is a wrapper around a Zerolog logger instance
 type Logger struct {
 	zerolog.Logger
+
+	// sampler is kept to derive the sampler of child loggers
+	sampler levelSampler
+	// hasRequestID and hasReconcileID are set if the fields were added, so the slog handler does not add them again
+	hasRequestID   bool
+	hasReconcileID bool
 }
 
 // ComponentLogger returns a new child logger that inherits all settings but adds a new component field
@@ -67,7 +114,14 @@ func (l *Logger) ComponentLogger(component string) *Logger {
 
 // SubLogger returns a new child logger that inherits all settings but adds a new string key field
 func (l *Logger) ChildLogger(key string, value string) *Logger {
-	return NewFromZerolog(l.With().Str(key, value).Logger())
+	child := l.derive(l.With().Str(key, value).Logger())
+	child.hasRequestID = child.hasRequestID || key == RequestIdLoggerKey
+	child.hasReconcileID = child.hasReconcileID || key == ReconcileIdLoggerKey
+	if l.sampler.levels != nil && key == componentFieldName {
+		child.sampler.component = value
+		child.Logger = child.Sample(child.sampler)
+	}
+	return child
 }
 
 var ErrInvalidKeyValPair = errors.New("invalid key value pair")
@@ -98,14 +152,43 @@ func (l *Logger) MustChildLoggerWithAttributes(keyVal ...string) *Logger {
 	return logger
 }
 
-// Level wraps the underlying zerolog level func to openmfp logger level
+// Level wraps the underlying zerolog level func to openmfp logger level.
+// The returned logger keeps the given level and no longer follows the LevelController.
 func (l *Logger) Level(lvl Level) *Logger {
-	return NewFromZerolog(l.Logger.Level(zerolog.Level(lvl)))
+	child := l.derive(l.Logger.Level(zerolog.Level(lvl)))
+	if child.sampler.levels != nil {
+		child.sampler = levelSampler{sampler: child.sampler.sampler}
+		child.Logger = child.Sample(child.sampler)
+	}
+	return child
 }
 
-// Logr returns a new logger that fulfills the log.Logr interface
+// LevelController returns the controller of the logger level, nil if the logger was not created by New
+func (l *Logger) LevelController() *LevelController {
+	return l.sampler.levels
+}
+
+// derive returns a logger for the zerolog logger which keeps the level controller and sampling
+func (l *Logger) derive(logger zerolog.Logger) *Logger {
+	return &Logger{Logger: logger, sampler: l.sampler, hasRequestID: l.hasRequestID, hasReconcileID: l.hasReconcileID}
+}
+
+// GetLevel returns the current level of the logger. For loggers created by New it is the level of the LevelController
+// for the component of the logger, as the zerolog level is not used by them.
+func (l *Logger) GetLevel() zerolog.Level {
+	if l.sampler.levels == nil {
+		return l.Logger.GetLevel()
+	}
+	return l.sampler.levels.componentLevel(l.sampler.component)
+}
+
+// Logr returns a new logger that fulfills the log.Logr interface.
+// V-levels of loggers created by New are enabled according to the LevelController.
 func (l *Logger) Logr() logr.Logger {
-	return zerologr.New(&l.Logger)
+	if l.sampler.levels == nil {
+		return zerologr.New(&l.Logger)
+	}
+	return logr.New(levelLogSink{LogSink: zerologr.NewLogSink(&l.Logger), sampler: l.sampler})
 }
 
 // New returns a new Logger instance for a given service name and log level
@@ -119,9 +202,54 @@ func New(config Config) (*Logger, error) {
 	if config.NoJSON {
 		logDest = zerolog.ConsoleWriter{Out: config.Output, TimeFormat: time.RFC3339}
 	}
+	// the JSON lines are redacted before they are written or formatted by the console writer
+	if !config.NoRedaction {
+		logDest = newRedactionWriter(logDest, config.RedactedKeys, config.RedactionPatterns)
+	}
+
+	zerologger := zerolog.New(logDest)
+	if config.Breadcrumbs {
+		breadcrumbLevel := zerologLevel
+		if config.BreadcrumbLevel != "" {
+			breadcrumbLevel, err = zerolog.ParseLevel(strings.ToLower(config.BreadcrumbLevel))
+			if err != nil {
+				return nil, err
+			}
+		}
+		// hooks only run for logged events, so lower breadcrumb levels would never be recorded
+		if breadcrumbLevel < zerologLevel {
+			return nil, fmt.Errorf("breadcrumb level %s is below the log level %s", breadcrumbLevel, zerologLevel)
+		}
+		zerologger = zerologger.Hook(NewBreadcrumbHook(breadcrumbLevel, config.BreadcrumbBufferSize))
+	}
+	if config.Trace {
+		zerologger = zerologger.Hook(NewTraceHook(config.TraceIDFieldName, config.SpanIDFieldName))
+	}
+
+	levels := config.LevelController
+	if levels == nil {
+		componentLevels, err := ParseComponentLevels(config.ComponentLevels)
+		if err != nil {
+			return nil, err
+		}
+		levels = NewLevelController(zerologLevel)
+		for component, level := range componentLevels {
+			levels.SetComponentLevel(component, level)
+		}
+	}
+	// the level is checked by the sampler, so it can be changed at runtime
+	sampler := levelSampler{levels: levels, sampler: newSampler(config)}
+	zerologger = zerologger.Level(zerolog.TraceLevel).Sample(sampler)
+
+	logContext := zerologger.With().Timestamp().Caller().Str("service", config.Name)
+	if config.Stack {
+		enableStackTraces(config.StackDepth)
+		logContext = logContext.Stack()
+	}
 
 	logger := &Logger{
-		Logger: zerolog.New(logDest).Level(zerologLevel).With().Timestamp().Caller().Str("service", config.Name).Logger(),
+		Logger:  logContext.Logger(),
+		sampler: sampler,
 	}
 
 	return logger, nil
@@ -129,32 +257,38 @@ func New(config Config) (*Logger, error) {
 
 // NewFromZerolog returns a new Logger from a Zerolog instance
 func NewFromZerolog(logger zerolog.Logger) *Logger {
-	return &Logger{logger}
+	return &Logger{Logger: logger}
 }
 
-// NewFromZerolog returns a new Logger from a Zerolog instance and adds the Request id to the logger Context
+// NewRequestLoggerFromZerolog returns a new Logger from a Zerolog instance and adds the Request id to the logger Context
 func NewRequestLoggerFromZerolog(ctx context.Context, logger zerolog.Logger) *Logger {
 	// Requesting value from ctx directly to avoid cyclic dependency to middleware package
 	var requestId string
 	if val, ok := ctx.Value(keys.RequestIdCtxKey).(string); ok {
 		requestId = val
 	}
-	logger = logger.With().Str(RequestIdLoggerKey, requestId).Logger()
-	return &Logger{logger}
+	// the context is added so hooks like the BreadcrumbHook can access it
+	logger = logger.With().Str(RequestIdLoggerKey, requestId).Ctx(ctx).Logger()
+	return &Logger{Logger: logger, hasRequestID: true}
 }
 
 func SetLoggerInContext(ctx context.Context, log *Logger) context.Context {
 	return context.WithValue(ctx, keys.LoggerCtxKey, log)
 }
 
-// LoadFromContext returns the Logger from a given context
+// LoadLoggerFromContext returns the Logger from a given context. If the context contains an active span or a Sentry hub,
+// the context is added to the returned logger so the TraceHook can log the trace and span ID and the BreadcrumbHook can
+// record breadcrumbs, e.g. on the hub added by the Sentry HTTP middleware after the logger was stored.
 func LoadLoggerFromContext(ctx context.Context) *Logger {
 	value := ctx.Value(keys.LoggerCtxKey)
 
 	log, ok := value.(*Logger)
 	if !ok {
-		return StdLogger
+		log = StdLogger
 	}
 
+	if hasSpan(ctx) || sentry.GetHubFromContext(ctx) != nil {
+		return log.derive(log.With().Ctx(ctx).Logger())
+	}
 	return log
 }
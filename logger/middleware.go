@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openmfp/golang-commons/context/keys"
+	"github.com/openmfp/golang-commons/internal/httputil"
+)
+
+const (
+	// RequestIdHeader is read and written by the RequestIDMiddleware
+	RequestIdHeader = "X-Request-Id"
+
+	// maxRequestIdLength limits request IDs provided by clients, longer IDs are replaced
+	maxRequestIdLength = 128
+)
+
+// RequestIDMiddleware returns a middleware which stores the request ID of the X-Request-Id header in the context and
+// the response header. A new ID is generated if the header is missing or invalid.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestId := r.Header.Get(RequestIdHeader)
+			if !isValidRequestId(requestId) {
+				requestId = uuid.New().String()
+			}
+			w.Header().Set(RequestIdHeader, requestId)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), keys.RequestIdCtxKey, requestId)))
+		})
+	}
+}
+
+// isValidRequestId accepts printable ASCII characters only, so client provided IDs can not forge log lines
+func isValidRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		return false
+	}
+	for _, c := range requestId {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// StoreLoggerMiddleware returns a middleware which stores a request logger in the context, see SetLoggerInContext.
+// The logger contains the request ID if the RequestIDMiddleware was applied before.
+func StoreLoggerMiddleware(log *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := SetLoggerInContext(r.Context(), log.requestLogger(r.Context()))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequestLoggingMiddleware returns a middleware which applies the RequestIDMiddleware and stores a request logger with
+// method and path in the context. The status, size and duration of the response are logged when the request completes.
+func RequestLoggingMiddleware(log *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		logRequest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			requestLog := log.requestLogger(r.Context()).MustChildLoggerWithAttributes("method", r.Method, "path", r.URL.Path)
+			recorder := httputil.NewResponseRecorder(w)
+
+			defer func() {
+				status := recorder.StatusCode()
+				// a panic in flight is answered with 500 by the server or an outer recovering middleware
+				rec := recover()
+				if rec != nil {
+					status = http.StatusInternalServerError
+				}
+				event := requestLog.Info()
+				if status >= http.StatusInternalServerError {
+					event = requestLog.Error()
+				}
+				event.Int("status", status).Int("size", recorder.Size()).Dur("duration", time.Since(start)).Msg("end request")
+				if rec != nil {
+					panic(rec)
+				}
+			}()
+
+			requestLog.Info().Msg("start request")
+			next.ServeHTTP(recorder, r.WithContext(SetLoggerInContext(r.Context(), requestLog)))
+		})
+		return RequestIDMiddleware()(logRequest)
+	}
+}
+
+// requestLogger returns a child logger containing the request ID of the context
+func (l *Logger) requestLogger(ctx context.Context) *Logger {
+	requestId, _ := ctx.Value(keys.RequestIdCtxKey).(string)
+	child := l.derive(l.With().Str(RequestIdLoggerKey, requestId).Ctx(ctx).Logger())
+	child.hasRequestID = true
+	return child
+}
@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openmfp/golang-commons/context/keys"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var requestId string
+	handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId, _ = r.Context().Value(keys.RequestIdCtxKey).(string)
+	}))
+
+	t.Run("Use the request ID of the header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIdHeader, "abc-123")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "abc-123", requestId)
+		assert.Equal(t, "abc-123", rec.Header().Get(RequestIdHeader))
+	})
+
+	t.Run("Generate a request ID without header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		_, err := uuid.Parse(requestId)
+		assert.NoError(t, err)
+		assert.Equal(t, requestId, rec.Header().Get(RequestIdHeader))
+	})
+
+	t.Run("Replace invalid request IDs", func(t *testing.T) {
+		for _, invalid := range []string{"abc 123", "abc\n{\"level\":\"error\"}", strings.Repeat("a", maxRequestIdLength+1)} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(RequestIdHeader, invalid)
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			_, err := uuid.Parse(requestId)
+			assert.NoError(t, err, invalid)
+		}
+	})
+}
+
+func TestStoreLoggerMiddleware(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log, err := New(Config{Level: "info", Output: buf})
+	require.NoError(t, err)
+
+	handler := RequestIDMiddleware()(StoreLoggerMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		LoadLoggerFromContext(r.Context()).Info().Msg("test")
+	})))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIdHeader, "abc-123")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	line := logLine(t, buf)
+	assert.Equal(t, "test", line["message"])
+	assert.Equal(t, "abc-123", line[RequestIdLoggerKey])
+}
+
+func TestRequestLoggingMiddleware(t *testing.T) {
+	t.Run("Log the completed request", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+
+		handler := RequestLoggingMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("hello"))
+		}))
+		req := httptest.NewRequest(http.MethodPost, "/items?id=1", nil)
+		req.Header.Set(RequestIdHeader, "abc-123")
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		line := endRequestLine(t, buf)
+		assert.Equal(t, "info", line["level"])
+		assert.Equal(t, "end request", line["message"])
+		assert.Equal(t, "abc-123", line[RequestIdLoggerKey])
+		assert.Equal(t, http.MethodPost, line["method"])
+		assert.Equal(t, "/items", line["path"])
+		assert.Equal(t, float64(http.StatusCreated), line["status"])
+		assert.Equal(t, float64(5), line["size"])
+		assert.Contains(t, line, "duration")
+	})
+
+	t.Run("Store the request logger in the context", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+
+		handler := RequestLoggingMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			LoadLoggerFromContext(r.Context()).Info().Msg("test")
+			LoadLoggerFromContext(r.Context()).Slog().InfoContext(r.Context(), "slog")
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIdHeader, "abc-123")
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 4)
+		for _, line := range lines[1:3] {
+			assert.Equal(t, 1, strings.Count(line, `"rid":"abc-123"`), line)
+			assert.Contains(t, line, `"path":"/"`)
+		}
+	})
+
+	t.Run("Log server errors as error", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+
+		handler := RequestLoggingMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "failed", http.StatusInternalServerError)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		line := endRequestLine(t, buf)
+		assert.Equal(t, "error", line["level"])
+		assert.Equal(t, float64(http.StatusInternalServerError), line["status"])
+	})
+
+	t.Run("Log panics as server errors", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+
+		handler := RequestLoggingMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("test panic")
+		}))
+
+		assert.PanicsWithValue(t, "test panic", func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+
+		line := endRequestLine(t, buf)
+		assert.Equal(t, "error", line["level"])
+		assert.Equal(t, float64(http.StatusInternalServerError), line["status"])
+	})
+
+	t.Run("Follow the level of the logger", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "warn", Output: buf})
+		require.NoError(t, err)
+
+		handler := RequestLoggingMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Empty(t, buf.String())
+
+		log.LevelController().SetLevel(zerolog.InfoLevel)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], "start request")
+		assert.Contains(t, lines[1], "end request")
+	})
+
+	t.Run("Support http.ResponseController", func(t *testing.T) {
+		log, err := New(Config{Level: "info", Output: &bytes.Buffer{}})
+		require.NoError(t, err)
+
+		handler := RequestLoggingMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, http.NewResponseController(w).Flush())
+		}))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.True(t, rec.Flushed)
+	})
+}
+
+// endRequestLine returns the end request line and checks that the start request line was logged with info level before
+func endRequestLine(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"level":"info"`)
+	assert.Contains(t, lines[0], `"message":"start request"`)
+	buf.Reset()
+	buf.WriteString(lines[1])
+	return logLine(t, buf)
+}
@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+
+	"github.com/rs/zerolog"
+
+	"github.com/openmfp/golang-commons/internal/redact"
+)
+
+// RedactedValue replaces values of redacted keys, matches of redaction patterns and sensitive values
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedKeys contains keys whose values are never logged. Keys match case-insensitive if they end with one of
+// them or their plural at a segment boundary of '_', '-', '.' or camelCase, e.g. clientSecret and x-api-key are redacted
+// but secretName and tokenizer are not. The Sentry scrubber denies them as well.
+var DefaultRedactedKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"authorization",
+	"cookie",
+	"apikey",
+	"api_key",
+	"credential",
+	"private_key",
+}
+
+// DefaultRedactionPatterns match JWTs, bearer tokens and email addresses. The Sentry scrubber uses them as well.
+var DefaultRedactionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/-]+=*`),
+	regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+}
+
+// SensitiveValue wraps a value which is logged as RedactedValue
+type SensitiveValue struct {
+	value interface{}
+}
+
+// Sensitive marks a value that must not be logged, e.g. log.Info().Stringer("user", logger.Sensitive(userID))
+func Sensitive(value interface{}) SensitiveValue {
+	return SensitiveValue{value: value}
+}
+
+// Value returns the wrapped value
+func (s SensitiveValue) Value() interface{} {
+	return s.value
+}
+
+// String implements fmt.Stringer
+func (s SensitiveValue) String() string {
+	return RedactedValue
+}
+
+// GoString implements fmt.GoStringer, so %#v does not print the value either
+func (s SensitiveValue) GoString() string {
+	return RedactedValue
+}
+
+// MarshalJSON implements json.Marshaler, which is used by Interface() and Any()
+func (s SensitiveValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedValue)
+}
+
+// redactionWriter redacts the JSON lines written by zerolog before they are written to the output or console writer
+type redactionWriter struct {
+	out      io.Writer
+	keys     *redact.Keys
+	patterns []*regexp.Regexp
+}
+
+func newRedactionWriter(out io.Writer, keys []string, patterns []*regexp.Regexp) *redactionWriter {
+	w := &redactionWriter{out: out, keys: redact.NewKeys(DefaultRedactedKeys...)}
+	w.keys.Add(keys...)
+	w.patterns = append(append(w.patterns, DefaultRedactionPatterns...), patterns...)
+	return w
+}
+
+// Write implements io.Writer
+func (w *redactionWriter) Write(p []byte) (int, error) {
+	if _, err := w.out.Write(w.redact(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteLevel implements zerolog.LevelWriter
+func (w *redactionWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	lw, ok := w.out.(zerolog.LevelWriter)
+	if !ok {
+		return w.Write(p)
+	}
+	if _, err := lw.WriteLevel(level, w.redact(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *redactionWriter) redact(p []byte) []byte {
+	for _, pattern := range w.patterns {
+		if pattern.Match(p) {
+			p = pattern.ReplaceAll(p, []byte(RedactedValue))
+		}
+	}
+
+	// only lines which may contain a redacted key are parsed
+	if w.keys.MayContain(bytes.ToLower(p)) {
+		if redacted, err := w.redactKeys(p); err == nil {
+			return redacted
+		}
+	}
+	return p
+}
+
+// redactKeys replaces the values of redacted keys and keeps the order of all fields
+func (w *redactionWriter) redactKeys(p []byte) ([]byte, error) {
+	line := bytes.TrimRight(p, "\n")
+	buf := &bytes.Buffer{}
+	if err := w.redactValue(buf, line); err != nil {
+		return nil, err
+	}
+	buf.Write(p[len(line):])
+	return buf.Bytes(), nil
+}
+
+func (w *redactionWriter) redactValue(buf *bytes.Buffer, raw []byte) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || (raw[0] != '{' && raw[0] != '[') {
+		buf.Write(raw)
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	isObject := raw[0] == '{'
+	if isObject {
+		buf.WriteByte('{')
+	} else {
+		buf.WriteByte('[')
+	}
+
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		redacted := false
+		if isObject {
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			keyJSON, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(keyJSON)
+			buf.WriteByte(':')
+			redacted = w.isRedacted(key)
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if redacted {
+			buf.WriteString(`"` + RedactedValue + `"`)
+			continue
+		}
+		if err := w.redactValue(buf, value); err != nil {
+			return err
+		}
+	}
+
+	if isObject {
+		buf.WriteByte('}')
+	} else {
+		buf.WriteByte(']')
+	}
+	return nil
+}
+
+func (w *redactionWriter) isRedacted(key string) bool {
+	return w.keys.Match(key)
+}
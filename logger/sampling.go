@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultSamplePeriod is the period of Config.SampleBurst if no other period is configured
+const DefaultSamplePeriod = time.Second
+
+// newSampler creates the sampler configured by SampleEvery and SampleBurst, nil if sampling is disabled.
+// Errors and events of higher levels are never dropped.
+func newSampler(config Config) zerolog.Sampler {
+	var sampler zerolog.Sampler
+	if config.SampleEvery > 1 {
+		sampler = &zerolog.BasicSampler{N: uint32(config.SampleEvery)}
+	}
+	if config.SampleBurst > 0 {
+		period := config.SamplePeriod
+		if period <= 0 {
+			period = DefaultSamplePeriod
+		}
+		sampler = &zerolog.BurstSampler{Burst: uint32(config.SampleBurst), Period: period, NextSampler: sampler}
+	}
+	if sampler == nil {
+		return nil
+	}
+
+	return zerolog.LevelSampler{
+		TraceSampler: sampler,
+		DebugSampler: sampler,
+		InfoSampler:  sampler,
+		WarnSampler:  sampler,
+	}
+}
+
+// WithoutSampling returns a child logger which logs all events of its level, e.g. for audit relevant events
+func (l *Logger) WithoutSampling() *Logger {
+	child := l.derive(l.Logger)
+	if child.sampler.sampler != nil {
+		child.sampler.sampler = nil
+		child.Logger = child.Sample(child.sampler)
+	}
+	return child
+}
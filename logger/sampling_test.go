@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countLines(buf *bytes.Buffer, msg string) int {
+	return strings.Count(buf.String(), `"message":"`+msg+`"`)
+}
+
+func TestSampling(t *testing.T) {
+	t.Run("Log every n-th event", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, SampleEvery: 3})
+		require.NoError(t, err)
+
+		for i := 0; i < 9; i++ {
+			log.Info().Msg("sampled")
+		}
+
+		assert.Equal(t, 3, countLines(buf, "sampled"))
+	})
+
+	t.Run("Limit the events per period", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, SampleBurst: 2, SamplePeriod: time.Hour})
+		require.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			log.Info().Msg("burst")
+		}
+
+		assert.Equal(t, 2, countLines(buf, "burst"))
+	})
+
+	t.Run("Sample events after the burst", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, SampleBurst: 2, SamplePeriod: time.Hour, SampleEvery: 2})
+		require.NoError(t, err)
+
+		for i := 0; i < 6; i++ {
+			log.Info().Msg("burst")
+		}
+
+		assert.Equal(t, 4, countLines(buf, "burst"))
+	})
+
+	t.Run("Never drop errors", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, SampleBurst: 1, SamplePeriod: time.Hour})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			log.Error().Msg("error")
+			log.Warn().Msg("warn")
+		}
+
+		assert.Equal(t, 3, countLines(buf, "error"))
+		assert.Equal(t, 1, countLines(buf, "warn"))
+	})
+
+	t.Run("Disable sampling for a child logger", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, SampleBurst: 1, SamplePeriod: time.Hour})
+		require.NoError(t, err)
+		audit := log.ComponentLogger("audit").WithoutSampling()
+
+		for i := 0; i < 3; i++ {
+			audit.ChildLogger("key", "value").Info().Msg("audit")
+			log.Info().Msg("sampled")
+		}
+		audit.Debug().Msg("audit debug")
+
+		assert.Equal(t, 3, countLines(buf, "audit"))
+		assert.Equal(t, 1, countLines(buf, "sampled"))
+		assert.Equal(t, 0, countLines(buf, "audit debug"))
+
+		log.LevelController().SetLevel(zerolog.DebugLevel)
+		audit.Debug().Msg("audit debug")
+		assert.Equal(t, 1, countLines(buf, "audit debug"))
+	})
+
+	t.Run("Keep sampling for a fixed level", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, SampleBurst: 1, SamplePeriod: time.Hour})
+		require.NoError(t, err)
+		fixed := log.Level(Level(zerolog.DebugLevel))
+
+		for i := 0; i < 3; i++ {
+			fixed.Debug().Msg("fixed")
+		}
+
+		assert.Equal(t, 1, countLines(buf, "fixed"))
+	})
+}
@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+
+	"github.com/openmfp/golang-commons/context/keys"
+)
+
+// slogCallerSkipFrames skips the frames of slog.Logger and the handler, so the caller field points to the slog call
+const slogCallerSkipFrames = 3
+
+// Slog returns a new logger that fulfills the slog.Logger interface and inherits settings and fields of the logger
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(NewSlogHandler(l))
+}
+
+// SlogHandler is a slog.Handler writing records to a Logger. Groups are logged as nested objects and the request ID and
+// reconcile ID of the context are added with the same field names as by the Logger.
+type SlogHandler struct {
+	logger *Logger
+	// attrs contains the attributes and groups added by WithAttrs and WithGroup in order
+	attrs []groupOrAttrs
+}
+
+// groupOrAttrs is either a group name or attributes
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// NewSlogHandler creates a slog.Handler writing to the logger
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	return &SlogHandler{logger: logger}
+}
+
+// Enabled implements slog.Handler
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	zerologLevel := toZerologLevel(level)
+	return zerologLevel >= h.logger.GetLevel() && zerologLevel >= zerolog.GlobalLevel()
+}
+
+// Handle implements slog.Handler
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	event := h.logger.WithLevel(toZerologLevel(record.Level))
+	if event == nil {
+		return nil
+	}
+	event = event.Ctx(ctx).CallerSkipFrame(slogCallerSkipFrames)
+
+	if requestID, ok := ctx.Value(keys.RequestIdCtxKey).(string); ok && requestID != "" && !h.logger.hasRequestID {
+		event = event.Str(RequestIdLoggerKey, requestID)
+	}
+	if reconcileID, ok := ctx.Value(keys.ReconcileIdCtxKey).(string); ok && reconcileID != "" && !h.logger.hasReconcileID {
+		event = event.Str(ReconcileIdLoggerKey, reconcileID)
+	}
+
+	recordAttrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		recordAttrs = append(recordAttrs, attr)
+		return true
+	})
+	addAttrs(event, h.attrs, recordAttrs)
+
+	event.Msg(record.Message)
+	return nil
+}
+
+// WithAttrs implements slog.Handler
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup implements slog.Handler
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+func (h *SlogHandler) with(goa groupOrAttrs) *SlogHandler {
+	attrs := make([]groupOrAttrs, 0, len(h.attrs)+1)
+	attrs = append(append(attrs, h.attrs...), goa)
+	return &SlogHandler{logger: h.logger, attrs: attrs}
+}
+
+// addAttrs adds the handler attributes followed by the record attributes, each group nests all later attributes
+func addAttrs(event *zerolog.Event, handlerAttrs []groupOrAttrs, recordAttrs []slog.Attr) {
+	for i, goa := range handlerAttrs {
+		if goa.group == "" {
+			for _, attr := range goa.attrs {
+				addAttr(event, attr)
+			}
+			continue
+		}
+		// groups without attributes are omitted
+		if !hasAttrs(handlerAttrs[i+1:], recordAttrs) {
+			return
+		}
+		dict := zerolog.Dict()
+		addAttrs(dict, handlerAttrs[i+1:], recordAttrs)
+		event.Dict(goa.group, dict)
+		return
+	}
+	for _, attr := range recordAttrs {
+		addAttr(event, attr)
+	}
+}
+
+func hasAttrs(handlerAttrs []groupOrAttrs, recordAttrs []slog.Attr) bool {
+	for _, goa := range handlerAttrs {
+		if len(goa.attrs) > 0 {
+			return true
+		}
+	}
+	return len(recordAttrs) > 0
+}
+
+func addAttr(event *zerolog.Event, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	// empty attributes are ignored as described by slog.Handler
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		groupAttrs := attr.Value.Group()
+		if len(groupAttrs) == 0 {
+			return
+		}
+		// attributes of groups without key are inlined
+		if attr.Key == "" {
+			for _, groupAttr := range groupAttrs {
+				addAttr(event, groupAttr)
+			}
+			return
+		}
+		dict := zerolog.Dict()
+		for _, groupAttr := range groupAttrs {
+			addAttr(dict, groupAttr)
+		}
+		event.Dict(attr.Key, dict)
+	case slog.KindString:
+		event.Str(attr.Key, attr.Value.String())
+	case slog.KindInt64:
+		event.Int64(attr.Key, attr.Value.Int64())
+	case slog.KindUint64:
+		event.Uint64(attr.Key, attr.Value.Uint64())
+	case slog.KindFloat64:
+		event.Float64(attr.Key, attr.Value.Float64())
+	case slog.KindBool:
+		event.Bool(attr.Key, attr.Value.Bool())
+	case slog.KindDuration:
+		event.Dur(attr.Key, attr.Value.Duration())
+	case slog.KindTime:
+		event.Time(attr.Key, attr.Value.Time())
+	default:
+		if err, ok := attr.Value.Any().(error); ok {
+			event.AnErr(attr.Key, err)
+			return
+		}
+		event.Interface(attr.Key, attr.Value.Any())
+	}
+}
+
+// toZerologLevel maps slog levels to zerolog levels, levels between the slog levels are rounded down
+func toZerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openmfp/golang-commons/context/keys"
+)
+
+func TestSlogHandlerConformance(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log, err := New(Config{Level: "debug", Output: buf})
+	require.NoError(t, err)
+
+	slogtest.Run(t, func(t *testing.T) slog.Handler {
+		buf.Reset()
+		return NewSlogHandler(log)
+	}, func(t *testing.T) map[string]any {
+		// the logger always adds the current time
+		if strings.Contains(t.Name(), "zero-time") {
+			t.Skip("the logger adds its own timestamp")
+		}
+		line := map[string]any{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		// the message is logged with the field name of zerolog
+		line[slog.MessageKey] = line[zerolog.MessageFieldName]
+		delete(line, zerolog.MessageFieldName)
+		return line
+	})
+}
+
+func TestSlog(t *testing.T) {
+	t.Run("Keep the field layout of the logger", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Name: "test", Level: "info", Output: buf})
+		require.NoError(t, err)
+
+		log.ComponentLogger("lib").Slog().With("key", "value").WithGroup("request").
+			Warn("test", "status", 500, "duration", time.Second, "error", errors.New("failed"))
+
+		line := logLine(t, buf)
+		assert.Equal(t, "warn", line["level"])
+		assert.Equal(t, "test", line["message"])
+		assert.Equal(t, "test", line["service"])
+		assert.Equal(t, "lib", line["component"])
+		assert.Equal(t, "value", line["key"])
+		assert.Equal(t, map[string]interface{}{"status": float64(500), "duration": float64(1000), "error": "failed"}, line["request"])
+		assert.Contains(t, line["caller"], "slog_test.go")
+		assert.Contains(t, line, "time")
+	})
+
+	t.Run("Add request and reconcile ID of the context", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf})
+		require.NoError(t, err)
+		ctx := context.WithValue(context.Background(), keys.RequestIdCtxKey, "request")
+		ctx = context.WithValue(ctx, keys.ReconcileIdCtxKey, "reconcile")
+
+		log.Slog().InfoContext(ctx, "test")
+		line := logLine(t, buf)
+		assert.Equal(t, "request", line[RequestIdLoggerKey])
+		assert.Equal(t, "reconcile", line[ReconcileIdLoggerKey])
+
+		log.ChildLogger(ReconcileIdLoggerKey, "reconcile").Slog().InfoContext(ctx, "test")
+		assert.Equal(t, 1, strings.Count(buf.String(), ReconcileIdLoggerKey))
+	})
+
+	t.Run("Follow the level controller", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, ComponentLevels: "lib=warn"})
+		require.NoError(t, err)
+		slogger := log.ComponentLogger("lib").Slog()
+
+		assert.False(t, slogger.Enabled(context.Background(), slog.LevelInfo))
+		assert.True(t, slogger.Enabled(context.Background(), slog.LevelWarn))
+		slogger.Info("info")
+		assert.Empty(t, buf.String())
+
+		log.LevelController().SetComponentLevel("lib", zerolog.DebugLevel)
+		assert.True(t, slogger.Enabled(context.Background(), slog.LevelDebug))
+		assert.False(t, log.Slog().Enabled(context.Background(), slog.LevelDebug))
+	})
+
+	t.Run("Map levels", func(t *testing.T) {
+		assert.Equal(t, zerolog.TraceLevel, toZerologLevel(slog.LevelDebug-1))
+		assert.Equal(t, zerolog.DebugLevel, toZerologLevel(slog.LevelDebug))
+		assert.Equal(t, zerolog.InfoLevel, toZerologLevel(slog.LevelInfo+2))
+		assert.Equal(t, zerolog.WarnLevel, toZerologLevel(slog.LevelWarn))
+		assert.Equal(t, zerolog.ErrorLevel, toZerologLevel(slog.LevelError+4))
+	})
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// DefaultStackDepth is the maximum number of frames logged if no depth is configured
+const DefaultStackDepth = 32
+
+// StackSkipPrefixes contains function name prefixes of frames which are dropped from logged stack traces
+var StackSkipPrefixes = []string{
+	"runtime.",
+	"github.com/pkg/errors.",
+	"github.com/openmfp/golang-commons/errors.",
+}
+
+// StackFrame is a single frame of a logged stack trace
+type StackFrame struct {
+	Function string `json:"func"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+// StackMarshaler returns a zerolog.ErrorStackMarshaler which logs the stack trace of an error as structured frames.
+// The deepest stack trace in the wrapped error chain is used, as it is closest to where the error originated.
+func StackMarshaler(maxDepth int) func(err error) interface{} {
+	if maxDepth <= 0 {
+		maxDepth = DefaultStackDepth
+	}
+	return func(err error) interface{} {
+		stack := deepestStackTrace(err)
+		if len(stack) == 0 {
+			return nil
+		}
+		return stackFrames(stack, maxDepth)
+	}
+}
+
+func deepestStackTrace(err error) pkgerrors.StackTrace {
+	var stack pkgerrors.StackTrace
+	for err != nil {
+		if tracer, ok := err.(stackTracer); ok {
+			stack = tracer.StackTrace()
+		}
+		err = errors.Unwrap(err)
+	}
+	return stack
+}
+
+func stackFrames(stack pkgerrors.StackTrace, maxDepth int) []StackFrame {
+	pcs := make([]uintptr, 0, len(stack))
+	for _, frame := range stack {
+		pcs = append(pcs, uintptr(frame))
+	}
+
+	result := make([]StackFrame, 0, min(len(pcs), maxDepth))
+	frames := runtime.CallersFrames(pcs)
+	for len(result) < maxDepth {
+		frame, more := frames.Next()
+		if frame.Function != "" && !skipFrame(frame.Function) {
+			result = append(result, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+func skipFrame(function string) bool {
+	for _, prefix := range StackSkipPrefixes {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// enableStackTraces sets the process wide zerolog.ErrorStackMarshaler, zerolog does not support it per logger
+func enableStackTraces(maxDepth int) {
+	zerolog.ErrorStackMarshaler = StackMarshaler(maxDepth)
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openmfp/golang-commons/errors"
+)
+
+func newStackError() error {
+	return errors.Wrap(errors.New("cause"), "wrapped")
+}
+
+func TestStackMarshaler(t *testing.T) {
+	t.Run("Logs the frames of the deepest stack", func(t *testing.T) {
+		frames, ok := StackMarshaler(0)(newStackError()).([]StackFrame)
+
+		require.True(t, ok)
+		require.NotEmpty(t, frames)
+		assert.Equal(t, "github.com/openmfp/golang-commons/logger.newStackError", frames[0].Function)
+		assert.Contains(t, frames[0].File, "stack_test.go")
+		assert.Positive(t, frames[0].Line)
+		for _, frame := range frames {
+			assert.False(t, skipFrame(frame.Function), frame.Function)
+		}
+	})
+
+	t.Run("Limits the depth", func(t *testing.T) {
+		frames := StackMarshaler(1)(newStackError()).([]StackFrame)
+		assert.Len(t, frames, 1)
+	})
+
+	t.Run("Returns nil for errors without stack", func(t *testing.T) {
+		assert.Nil(t, StackMarshaler(0)(fmt.Errorf("no stack")))
+	})
+}
+
+func TestNewWithStack(t *testing.T) {
+	buf := &bytes.Buffer{}
+	cfg := DefaultConfig()
+	cfg.Output = buf
+	cfg.Stack = true
+	cfg.StackDepth = 2
+	log, err := New(cfg)
+	require.NoError(t, err)
+
+	log.ComponentLogger("test").Error().Err(newStackError()).Msg("failed")
+
+	var entry struct {
+		Stack []StackFrame `json:"stack"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Len(t, entry.Stack, 2)
+	assert.Equal(t, "github.com/openmfp/golang-commons/logger.newStackError", entry.Stack[0].Function)
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultTraceIDFieldName is the field containing the trace ID if no other field name is configured
+	DefaultTraceIDFieldName = "trace_id"
+	// DefaultSpanIDFieldName is the field containing the span ID if no other field name is configured
+	DefaultSpanIDFieldName = "span_id"
+)
+
+// TraceHook is a zerolog hook which adds the trace and span ID of the active OpenTelemetry span of the event context.
+// Events without context or without a span in their context are not changed, use Ctx(), LoadLoggerFromContext or
+// NewRequestLoggerFromZerolog to add the context.
+type TraceHook struct {
+	traceIDFieldName string
+	spanIDFieldName  string
+}
+
+// NewTraceHook creates a hook writing the IDs to the given fields, the defaults are used for empty field names
+func NewTraceHook(traceIDFieldName, spanIDFieldName string) *TraceHook {
+	if traceIDFieldName == "" {
+		traceIDFieldName = DefaultTraceIDFieldName
+	}
+	if spanIDFieldName == "" {
+		spanIDFieldName = DefaultSpanIDFieldName
+	}
+	return &TraceHook{traceIDFieldName: traceIDFieldName, spanIDFieldName: spanIDFieldName}
+}
+
+// Run implements zerolog.Hook
+func (h *TraceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	spanContext := trace.SpanContextFromContext(e.GetCtx())
+	if !spanContext.IsValid() {
+		return
+	}
+	e.Str(h.traceIDFieldName, spanContext.TraceID().String()).Str(h.spanIDFieldName, spanContext.SpanID().String())
+}
+
+// hasSpan returns true if the context contains a valid span context
+func hasSpan(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
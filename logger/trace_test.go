@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testTraceID = "0102030405060708090a0b0c0d0e0f10"
+	testSpanID  = "0102030405060708"
+)
+
+func contextWithSpan(t *testing.T) context.Context {
+	traceID, err := trace.TraceIDFromHex(testTraceID)
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex(testSpanID)
+	require.NoError(t, err)
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	return trace.ContextWithSpanContext(context.Background(), spanContext)
+}
+
+func logLine(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	line := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	buf.Reset()
+	return line
+}
+
+func TestTraceHook(t *testing.T) {
+	t.Run("Add the IDs of the active span", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, Trace: true})
+		require.NoError(t, err)
+
+		log.Info().Ctx(contextWithSpan(t)).Msg("with span")
+
+		line := logLine(t, buf)
+		assert.Equal(t, testTraceID, line[DefaultTraceIDFieldName])
+		assert.Equal(t, testSpanID, line[DefaultSpanIDFieldName])
+	})
+
+	t.Run("Skip events without span", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, Trace: true})
+		require.NoError(t, err)
+
+		log.Info().Msg("without context")
+		log.Info().Ctx(context.Background()).Msg("without span")
+
+		for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+			assert.NotContains(t, string(line), DefaultTraceIDFieldName)
+		}
+	})
+
+	t.Run("Use the configured field names", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log, err := New(Config{Level: "info", Output: buf, Trace: true, TraceIDFieldName: "traceId", SpanIDFieldName: "spanId"})
+		require.NoError(t, err)
+
+		log.Info().Ctx(contextWithSpan(t)).Msg("with span")
+
+		line := logLine(t, buf)
+		assert.Equal(t, testTraceID, line["traceId"])
+		assert.Equal(t, testSpanID, line["spanId"])
+	})
+}
+
+func TestLoadLoggerFromContextWithSpan(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log, err := New(Config{Level: "info", Output: buf, Trace: true})
+	require.NoError(t, err)
+	ctx := SetLoggerInContext(contextWithSpan(t), log)
+
+	LoadLoggerFromContext(ctx).Info().Msg("loaded from context")
+
+	line := logLine(t, buf)
+	assert.Equal(t, testTraceID, line[DefaultTraceIDFieldName])
+	assert.Equal(t, testSpanID, line[DefaultSpanIDFieldName])
+	assert.Same(t, log, LoadLoggerFromContext(SetLoggerInContext(context.Background(), log)))
+}
@@ -6,9 +6,63 @@ import (
 	"github.com/openmfp/golang-commons/context/keys"
 )
 
+// GetSentryTagsFromContext returns a copy of the tags of the context, the result is empty if no tags were added
 func GetSentryTagsFromContext(ctx context.Context) map[string]string {
-	return ctx.Value(keys.SentryTagsCtxKey).(map[string]string)
+	tags, _ := ctx.Value(keys.SentryTagsCtxKey).(map[string]string)
+	result := make(map[string]string, len(tags))
+	for k, v := range tags {
+		result[k] = v
+	}
+	return result
 }
+
+// ContextWithSentryTags returns a child context containing the tags of the parent context and the given tags.
+// Given tags overwrite tags of the parent with the same key, the parent context is not modified.
 func ContextWithSentryTags(ctx context.Context, sentryTags map[string]string) context.Context {
-	return context.WithValue(ctx, keys.SentryTagsCtxKey, sentryTags)
+	tags := GetSentryTagsFromContext(ctx)
+	for k, v := range sentryTags {
+		tags[k] = v
+	}
+	return context.WithValue(ctx, keys.SentryTagsCtxKey, tags)
+}
+
+// GetSentryExtrasFromContext returns a copy of the extras of the context, the result is empty if no extras were added
+func GetSentryExtrasFromContext(ctx context.Context) Extras {
+	extras, _ := ctx.Value(keys.SentryExtrasCtxKey).(Extras)
+	result := make(Extras, len(extras))
+	for k, v := range extras {
+		result[k] = v
+	}
+	return result
+}
+
+// ContextWithSentryExtras returns a child context containing the extras of the parent context and the given extras.
+// Given extras overwrite extras of the parent with the same key, the parent context is not modified.
+func ContextWithSentryExtras(ctx context.Context, sentryExtras Extras) context.Context {
+	extras := GetSentryExtrasFromContext(ctx)
+	for k, v := range sentryExtras {
+		extras[k] = v
+	}
+	return context.WithValue(ctx, keys.SentryExtrasCtxKey, extras)
+}
+
+// CaptureErrorWithContext sends an error to Sentry with the tags and extras of the context merged with the provided
+// ones, which take precedence. The hub of the context is used if available, e.g. the one of the HTTP middleware.
+func CaptureErrorWithContext(ctx context.Context, err error, tags Tags, extras ...Extras) {
+	captureError(ctx, hubFromContext(ctx), err, mergeTags(ctx, tags), append([]Extras{GetSentryExtrasFromContext(ctx)}, extras...)...)
+}
+
+// CaptureSentryErrorWithContext is a small wrapper around CaptureErrorWithContext that only captures Sentry errors
+func CaptureSentryErrorWithContext(ctx context.Context, err error, tags Tags, extras ...Extras) {
+	if IsSentryError(err) {
+		CaptureErrorWithContext(ctx, err, tags, extras...)
+	}
+}
+
+func mergeTags(ctx context.Context, tags Tags) Tags {
+	merged := Tags(GetSentryTagsFromContext(ctx))
+	for k, v := range tags {
+		merged.Add(k, v)
+	}
+	return merged
 }
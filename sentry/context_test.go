@@ -2,9 +2,12 @@ package sentry
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/openmfp/golang-commons/sentry/testsentry"
 )
 
 func TestContextWithSentryTags(t *testing.T) {
@@ -16,3 +19,51 @@ func TestContextWithSentryTags(t *testing.T) {
 	ctx = ContextWithSentryTags(ctx, tags)
 	assert.Equal(t, tags, GetSentryTagsFromContext(ctx))
 }
+
+func TestGetSentryTagsFromEmptyContext(t *testing.T) {
+	assert.Empty(t, GetSentryTagsFromContext(context.Background()))
+	assert.Empty(t, GetSentryExtrasFromContext(context.Background()))
+}
+
+func TestContextWithSentryTagsKeepsParent(t *testing.T) {
+	parent := ContextWithSentryTags(context.Background(), Tags{"name": "parent", "namespace": "default"})
+
+	child := ContextWithSentryTags(parent, Tags{"name": "child", "subroutine": "test"})
+
+	assert.Equal(t, map[string]string{"name": "parent", "namespace": "default"}, GetSentryTagsFromContext(parent))
+	assert.Equal(t, map[string]string{"name": "child", "namespace": "default", "subroutine": "test"}, GetSentryTagsFromContext(child))
+}
+
+func TestContextWithSentryExtrasKeepsParent(t *testing.T) {
+	parent := ContextWithSentryExtras(context.Background(), Extras{"a": 1})
+
+	child := ContextWithSentryExtras(parent, Extras{"b": 2})
+
+	assert.Equal(t, Extras{"a": 1}, GetSentryExtrasFromContext(parent))
+	assert.Equal(t, Extras{"a": 1, "b": 2}, GetSentryExtrasFromContext(child))
+}
+
+func TestCaptureErrorWithContext(t *testing.T) {
+	transport := testsentry.Init(t)
+	ctx := ContextWithSentryTags(context.Background(), Tags{"name": "context", "namespace": "default"})
+	ctx = ContextWithSentryExtras(ctx, Extras{"operation": "update"})
+
+	CaptureErrorWithContext(ctx, errors.New("test error"), Tags{"name": "explicit"}, Extras{"attempt": 2})
+
+	transport.AssertEventCount(t, 1)
+	transport.AssertTag(t, "name", "explicit")
+	transport.AssertTag(t, "namespace", "default")
+	transport.AssertExtra(t, "operation", "update")
+	transport.AssertExtra(t, "attempt", 2)
+}
+
+func TestCaptureSentryErrorWithContext(t *testing.T) {
+	transport := testsentry.Init(t)
+	ctx := ContextWithSentryTags(context.Background(), Tags{"name": "context"})
+
+	CaptureSentryErrorWithContext(ctx, errors.New("test error"), nil)
+	CaptureSentryErrorWithContext(ctx, SentryError(errors.New("test error")), nil)
+
+	transport.AssertEventCount(t, 1)
+	transport.AssertTag(t, "name", "context")
+}
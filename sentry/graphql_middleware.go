@@ -9,6 +9,7 @@ import (
 	"github.com/vektah/gqlparser/v2/gqlerror"
 
 	openmfpcontext "github.com/openmfp/golang-commons/context"
+	"github.com/openmfp/golang-commons/errors"
 	"github.com/openmfp/golang-commons/logger"
 )
 
@@ -19,6 +20,7 @@ func GraphQLErrorPresenter(skipTenants ...string) graphql.ErrorPresenterFunc {
 		if err == nil {
 			return nil
 		}
+		err = errors.AddGraphQLExtensions(err, e)
 
 		if !IsSentryError(e) {
 			l := logger.LoadLoggerFromContext(ctx)
@@ -76,7 +78,7 @@ func GraphQLRecover(log *logger.Logger) graphql.RecoverFunc {
 	}
 }
 
-// captureErrorForContext sends the error to Sentry and adds tags and extras from context if possible
+// captureErrorForContext sends the error to Sentry and adds tags and extras from the context and the GraphQL operation
 func captureErrorForContext(ctx context.Context, err error, tenantID string) {
 	extras := Extras{}
 	tags := Tags{}
@@ -98,5 +100,5 @@ func captureErrorForContext(ctx context.Context, err error, tenantID string) {
 		tags.Add("tenantID", tenantID)
 	}
 
-	CaptureError(err, tags, extras)
+	CaptureErrorWithContext(ctx, err, tags, extras)
 }
@@ -12,8 +12,10 @@ import (
 	"github.com/vektah/gqlparser/v2/gqlerror"
 
 	openmfpcontext "github.com/openmfp/golang-commons/context"
+	openmfperrors "github.com/openmfp/golang-commons/errors"
 	"github.com/openmfp/golang-commons/jwt"
 	testlogger "github.com/openmfp/golang-commons/logger/testlogger"
+	"github.com/openmfp/golang-commons/sentry/testsentry"
 )
 
 func TestGraphQLRecover(t *testing.T) {
@@ -105,3 +107,51 @@ func TestGraphQLErrorPresenterWithSkipTenants(t *testing.T) {
 	assert.Len(t, messages, 1)
 	assert.Equal(t, "Error not sent to Sentry for skipped tenant", messages[0].Message)
 }
+
+func TestGraphQLErrorPresenterWithErrorCode(t *testing.T) {
+	//Given
+	presenter := GraphQLErrorPresenter()
+	testError := openmfperrors.WithCode(errors.New("test error"), openmfperrors.CodeNotFound)
+	ctx := openmfpcontext.AddTenantToContext(context.Background(), "test")
+
+	//When
+	err := presenter(ctx, testError)
+
+	//Then
+	assert.Equal(t, "test error", err.Message)
+	assert.Equal(t, "NOT_FOUND", err.Extensions[openmfperrors.GraphQLCodeExtension])
+	assert.Equal(t, "NOT_FOUND", err.Extensions[openmfperrors.GraphQLClassificationExtension])
+}
+
+func TestGraphQLErrorPresenterCapturesSentryError(t *testing.T) {
+	//Given
+	transport := testsentry.Init(t)
+	presenter := GraphQLErrorPresenter()
+	ctx := openmfpcontext.AddTenantToContext(context.Background(), "test")
+	ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{
+		Operation: &ast.OperationDefinition{Operation: ast.Mutation},
+		RawQuery:  "mutation { test }",
+	})
+
+	//When
+	presenter(ctx, SentryError(errors.New("test error")))
+
+	//Then
+	transport.AssertEventCount(t, 1)
+	transport.AssertException(t, "test error")
+	transport.AssertTag(t, "tenantID", "test")
+	transport.AssertExtra(t, "query", "mutation { test }")
+}
+
+func TestGraphQLErrorPresenterSkipsNonSentryError(t *testing.T) {
+	//Given
+	transport := testsentry.Init(t)
+	presenter := GraphQLErrorPresenter()
+	ctx := openmfpcontext.AddTenantToContext(context.Background(), "test")
+
+	//When
+	presenter(ctx, errors.New("test error"))
+
+	//Then
+	transport.AssertNoEvents(t)
+}
@@ -0,0 +1,202 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime/debug"
+
+	"github.com/getsentry/sentry-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	openmfpcontext "github.com/openmfp/golang-commons/context"
+	"github.com/openmfp/golang-commons/logger"
+)
+
+// DefaultIgnoredCodes contains the gRPC status codes caused by callers which are not reported by default
+var DefaultIgnoredCodes = []codes.Code{
+	codes.Canceled,
+	codes.InvalidArgument,
+	codes.NotFound,
+	codes.AlreadyExists,
+	codes.PermissionDenied,
+	codes.Unauthenticated,
+}
+
+// GRPCOption configures the gRPC interceptors
+type GRPCOption func(*grpcOptions)
+
+type grpcOptions struct {
+	ignoredCodes map[codes.Code]struct{}
+	repanic      bool
+}
+
+// WithIgnoredCodes replaces the status codes which are not reported, DefaultIgnoredCodes are used if not set
+func WithIgnoredCodes(ignoredCodes ...codes.Code) GRPCOption {
+	return func(o *grpcOptions) {
+		o.ignoredCodes = map[codes.Code]struct{}{}
+		for _, code := range ignoredCodes {
+			o.ignoredCodes[code] = struct{}{}
+		}
+	}
+}
+
+// WithGRPCRepanic panics again after a recovered panic of a server handler was captured
+func WithGRPCRepanic() GRPCOption {
+	return func(o *grpcOptions) {
+		o.repanic = true
+	}
+}
+
+func newGRPCOptions(opts []GRPCOption) *grpcOptions {
+	o := &grpcOptions{}
+	WithIgnoredCodes(DefaultIgnoredCodes...)(o)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// capture sends the error to Sentry unless its status code is ignored. Errors returned by servers have to carry a
+// sentry.Error, errors received by clients can not carry it and are reported based on their status code only.
+func (o *grpcOptions) capture(ctx context.Context, hub *sentry.Hub, err error, tags Tags, requireSentryError bool) {
+	if err == nil || (requireSentryError && !IsSentryError(err)) {
+		return
+	}
+	code := status.Code(err)
+	if _, ok := o.ignoredCodes[code]; ok {
+		return
+	}
+
+	eventTags := Tags{"code": code.String()}
+	for k, v := range tags {
+		eventTags.Add(k, v)
+	}
+	captureError(ctx, hub, err, eventTags)
+}
+
+// recoverPanic captures a recovered panic and returns the error sent to the client
+func (o *grpcOptions) recoverPanic(ctx context.Context, hub *sentry.Hub, rec interface{}) error {
+	log := logger.LoadLoggerFromContext(ctx)
+	log.Error().Interface("panic", rec).Interface("stack", debug.Stack()).Msg("recovered gRPC panic")
+	hub.Scope().SetTags(traceTags(ctx))
+	recordEventID(ctx, hub.RecoverWithContext(ctx, rec))
+	if o.repanic {
+		panic(rec)
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func grpcTags(ctx context.Context, method string) Tags {
+	tags := Tags{"method": method}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		tags.Add("peer", p.Addr.String())
+	}
+	if tenantID, err := openmfpcontext.GetTenantFromContext(ctx); err == nil {
+		tags.Add("tenantID", tenantID)
+	}
+	return tags
+}
+
+// newServerHub creates a hub per call which is tagged with the call details
+func newServerHub(ctx context.Context, method string) (context.Context, *sentry.Hub) {
+	hub := sentry.CurrentHub().Clone()
+	hub.Scope().SetTags(grpcTags(ctx, method))
+	return sentry.SetHubOnContext(ctx, hub), hub
+}
+
+// UnaryServerInterceptor creates a Sentry hub per call, recovers panics and captures returned errors carrying a sentry.Error
+func UnaryServerInterceptor(opts ...GRPCOption) grpc.UnaryServerInterceptor {
+	o := newGRPCOptions(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx, hub := newServerHub(ctx, info.FullMethod)
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = o.recoverPanic(ctx, hub, rec)
+			}
+		}()
+
+		resp, err = handler(ctx, req)
+		o.capture(ctx, hub, err, nil, true)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor creates a Sentry hub per stream, recovers panics and captures returned errors carrying a sentry.Error
+func StreamServerInterceptor(opts ...GRPCOption) grpc.StreamServerInterceptor {
+	o := newGRPCOptions(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, hub := newServerHub(ss.Context(), info.FullMethod)
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = o.recoverPanic(ctx, hub, rec)
+			}
+		}()
+
+		err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		o.capture(ctx, hub, err, nil, true)
+		return err
+	}
+}
+
+// serverStream provides the context containing the hub to the stream handler
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor captures errors returned by calls
+func UnaryClientInterceptor(opts ...GRPCOption) grpc.UnaryClientInterceptor {
+	o := newGRPCOptions(opts)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		o.capture(ctx, hubFromContext(ctx), err, clientTags(ctx, method, cc), false)
+		return err
+	}
+}
+
+// StreamClientInterceptor captures errors returned when creating or receiving from streams
+func StreamClientInterceptor(opts ...GRPCOption) grpc.StreamClientInterceptor {
+	o := newGRPCOptions(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		tags := clientTags(ctx, method, cc)
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			o.capture(ctx, hubFromContext(ctx), err, tags, false)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, ctx: ctx, hub: hubFromContext(ctx), tags: tags, options: o}, nil
+	}
+}
+
+func clientTags(ctx context.Context, method string, cc *grpc.ClientConn) Tags {
+	tags := grpcTags(ctx, method)
+	if cc != nil {
+		tags.Add("peer", cc.Target())
+	}
+	return tags
+}
+
+// clientStream captures errors received from the stream
+type clientStream struct {
+	grpc.ClientStream
+	ctx     context.Context
+	hub     *sentry.Hub
+	tags    Tags
+	options *grpcOptions
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.options.capture(s.ctx, s.hub, err, s.tags, false)
+	}
+	return err
+}
@@ -0,0 +1,192 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	openmfpcontext "github.com/openmfp/golang-commons/context"
+	"github.com/openmfp/golang-commons/sentry/testsentry"
+)
+
+const testMethod = "/openmfp.Test/Method"
+
+func grpcTestContext() context.Context {
+	ctx := openmfpcontext.AddTenantToContext(context.Background(), "tenant")
+	return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+
+	t.Run("Capture Sentry error", func(t *testing.T) {
+		transport := testsentry.Init(t)
+		interceptor := UnaryServerInterceptor()
+
+		_, err := interceptor(grpcTestContext(), nil, info, func(ctx context.Context, req any) (any, error) {
+			assert.NotNil(t, sentry.GetHubFromContext(ctx))
+			return nil, SentryError(status.Error(codes.Unavailable, "unavailable"))
+		})
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		events := transport.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, map[string]string{
+			"method":   testMethod,
+			"peer":     "127.0.0.1:8080",
+			"tenantID": "tenant",
+			"code":     "Unavailable",
+		}, events[0].Tags)
+	})
+
+	t.Run("Ignore errors which are no Sentry errors or have ignored codes", func(t *testing.T) {
+		transport := testsentry.Init(t)
+		interceptor := UnaryServerInterceptor(WithIgnoredCodes(codes.Internal))
+
+		for _, handlerErr := range []error{
+			errors.New("test error"),
+			SentryError(status.Error(codes.Internal, "internal")),
+		} {
+			_, err := interceptor(grpcTestContext(), nil, info, func(ctx context.Context, req any) (any, error) {
+				return nil, handlerErr
+			})
+			assert.Error(t, err)
+		}
+
+		assert.Empty(t, transport.Events())
+	})
+
+	t.Run("Ignore default codes", func(t *testing.T) {
+		transport := testsentry.Init(t)
+		interceptor := UnaryServerInterceptor()
+
+		_, err := interceptor(grpcTestContext(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, SentryError(status.Error(codes.NotFound, "not found"))
+		})
+
+		assert.Error(t, err)
+		assert.Empty(t, transport.Events())
+	})
+
+	t.Run("Recover panic", func(t *testing.T) {
+		transport := testsentry.Init(t)
+		interceptor := UnaryServerInterceptor()
+
+		_, err := interceptor(grpcTestContext(), nil, info, func(ctx context.Context, req any) (any, error) {
+			panic("oh nose")
+		})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+		events := transport.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "oh nose", events[0].Message)
+		assert.Equal(t, testMethod, events[0].Tags["method"])
+	})
+
+	t.Run("Repanic", func(t *testing.T) {
+		testsentry.Init(t)
+		interceptor := UnaryServerInterceptor(WithGRPCRepanic())
+
+		assert.Panics(t, func() {
+			_, _ = interceptor(grpcTestContext(), nil, info, func(ctx context.Context, req any) (any, error) {
+				panic("oh nose")
+			})
+		})
+	})
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	transport := testsentry.Init(t)
+	interceptor := StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: testMethod}
+
+	err := interceptor(nil, &testServerStream{ctx: grpcTestContext()}, info, func(srv any, stream grpc.ServerStream) error {
+		assert.NotNil(t, sentry.GetHubFromContext(stream.Context()))
+		return SentryError(errors.New("test error"))
+	})
+
+	assert.Error(t, err)
+	events := transport.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "Unknown", events[0].Tags["code"])
+	assert.Equal(t, "tenant", events[0].Tags["tenantID"])
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	transport := testsentry.Init(t)
+	interceptor := UnaryClientInterceptor()
+
+	for _, invokeErr := range []error{status.Error(codes.Unavailable, "unavailable"), status.Error(codes.NotFound, "not found"), nil} {
+		err := interceptor(grpcTestContext(), testMethod, nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return invokeErr
+		})
+		assert.Equal(t, invokeErr, err)
+	}
+
+	events := transport.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "Unavailable", events[0].Tags["code"])
+	assert.Equal(t, testMethod, events[0].Tags["method"])
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+	errs []error
+}
+
+func (s *testClientStream) RecvMsg(_ any) error {
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	t.Run("Capture receive errors", func(t *testing.T) {
+		transport := testsentry.Init(t)
+		interceptor := StreamClientInterceptor()
+
+		cs, err := interceptor(grpcTestContext(), &grpc.StreamDesc{}, nil, testMethod, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &testClientStream{errs: []error{nil, io.EOF, status.Error(codes.Internal, "internal")}}, nil
+		})
+		require.NoError(t, err)
+
+		assert.NoError(t, cs.RecvMsg(nil))
+		assert.ErrorIs(t, cs.RecvMsg(nil), io.EOF)
+		assert.Error(t, cs.RecvMsg(nil))
+
+		events := transport.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "Internal", events[0].Tags["code"])
+	})
+
+	t.Run("Capture stream creation errors", func(t *testing.T) {
+		transport := testsentry.Init(t)
+		interceptor := StreamClientInterceptor()
+
+		_, err := interceptor(grpcTestContext(), &grpc.StreamDesc{}, nil, testMethod, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return nil, status.Error(codes.Unavailable, "unavailable")
+		})
+
+		assert.Error(t, err)
+		assert.Len(t, transport.Events(), 1)
+	})
+}
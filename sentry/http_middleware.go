@@ -0,0 +1,169 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	openmfpcontext "github.com/openmfp/golang-commons/context"
+	"github.com/openmfp/golang-commons/context/keys"
+	"github.com/openmfp/golang-commons/internal/httputil"
+	"github.com/openmfp/golang-commons/logger"
+)
+
+// DefaultFlushTimeout is the time waited for events to be sent when the middleware context is done
+const DefaultFlushTimeout = 5 * time.Second
+
+// HTTPMiddlewareOption configures the HTTP middleware
+type HTTPMiddlewareOption func(*httpMiddlewareOptions)
+
+type httpMiddlewareOptions struct {
+	repanic      bool
+	flushTimeout time.Duration
+}
+
+// WithRepanic panics again after a recovered panic was captured, e.g. to let an outer middleware handle it
+func WithRepanic() HTTPMiddlewareOption {
+	return func(o *httpMiddlewareOptions) {
+		o.repanic = true
+	}
+}
+
+// WithFlushTimeout sets the time waited for events to be sent on shutdown
+func WithFlushTimeout(timeout time.Duration) HTTPMiddlewareOption {
+	return func(o *httpMiddlewareOptions) {
+		o.flushTimeout = timeout
+	}
+}
+
+type requestErrorKey struct{}
+
+type requestError struct {
+	mu  sync.Mutex
+	err error
+}
+
+// SetRequestError records an error for the current request. If the response has a 5xx status and the error is a
+// sentry.Error, the HTTP middleware sends it to Sentry.
+func SetRequestError(ctx context.Context, err error) {
+	holder, ok := ctx.Value(requestErrorKey{}).(*requestError)
+	if !ok {
+		return
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	holder.err = err
+}
+
+func (r *requestError) get() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// HTTPMiddleware returns a middleware which creates a Sentry hub per request, tags it with the request details found in
+// the context, recovers panics and captures errors of 5xx responses recorded by SetRequestError.
+// Events are flushed when the given context is done. Place it after middlewares adding tenant, token or spiffe ID to
+// the context so these are available as tags.
+func HTTPMiddleware(ctx context.Context, opts ...HTTPMiddlewareOption) func(http.Handler) http.Handler {
+	o := &httpMiddlewareOptions{flushTimeout: DefaultFlushTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	go func() {
+		<-ctx.Done()
+		sentry.Flush(o.flushTimeout)
+	}()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hub := sentry.CurrentHub().Clone()
+			hub.Scope().SetRequest(r)
+			hub.Scope().SetTags(requestTags(r))
+
+			holder := &requestError{}
+			ctx := sentry.SetHubOnContext(r.Context(), hub)
+			ctx = context.WithValue(ctx, requestErrorKey{}, holder)
+			r = r.WithContext(ctx)
+			recorder := httputil.NewResponseRecorder(w)
+
+			defer func() {
+				// the route is known after the request was routed by an inner http.ServeMux
+				hub.Scope().SetTag("route", route(r))
+				if rec := recover(); rec != nil {
+					recoverHTTPPanic(r, hub, rec, recorder, o.repanic)
+					return
+				}
+				captureRequestError(r.Context(), hub, holder.get(), recorder.StatusCode())
+			}()
+
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
+func requestTags(r *http.Request) map[string]string {
+	ctx := r.Context()
+	tags := map[string]string{"method": r.Method}
+	if tenantID, err := openmfpcontext.GetTenantFromContext(ctx); err == nil {
+		tags["tenantID"] = tenantID
+	}
+	if webToken, err := openmfpcontext.GetWebTokenFromContext(ctx); err == nil && webToken.Subject != "" {
+		tags["user"] = webToken.Subject
+	}
+	if spiffe, err := openmfpcontext.GetSpiffeFromContext(ctx); err == nil {
+		tags["spiffe"] = spiffe
+	}
+	// Requesting value from ctx directly as there is no getter for the request id
+	if requestID, ok := ctx.Value(keys.RequestIdCtxKey).(string); ok && requestID != "" {
+		tags["requestID"] = requestID
+	}
+	return tags
+}
+
+func route(r *http.Request) string {
+	if r.Pattern != "" {
+		return r.Pattern
+	}
+	return r.URL.Path
+}
+
+func recoverHTTPPanic(r *http.Request, hub *sentry.Hub, rec interface{}, recorder *httputil.ResponseRecorder, repanic bool) {
+	// http.ErrAbortHandler is used to abort a response and must not be reported
+	if rec == http.ErrAbortHandler {
+		panic(rec)
+	}
+
+	log := logger.LoadLoggerFromContext(r.Context())
+	log.Error().Interface("panic", rec).Interface("stack", debug.Stack()).Msg("recovered HTTP panic")
+	hub.Scope().SetTags(traceTags(r.Context()))
+	recordEventID(r.Context(), hub.RecoverWithContext(r.Context(), rec))
+
+	if repanic {
+		panic(rec)
+	}
+	if !recorder.WroteHeader() {
+		http.Error(recorder, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+func captureRequestError(ctx context.Context, hub *sentry.Hub, err error, status int) {
+	if status < http.StatusInternalServerError || !IsSentryError(err) {
+		return
+	}
+	captureError(ctx, hub, err, Tags{"status": fmt.Sprint(status)})
+}
+
+// hubFromContext returns the request hub created by the HTTP middleware or the current hub
+func hubFromContext(ctx context.Context) *sentry.Hub {
+	if hub := sentry.GetHubFromContext(ctx); hub != nil {
+		return hub
+	}
+	return sentry.CurrentHub()
+}
@@ -0,0 +1,139 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	openmfpcontext "github.com/openmfp/golang-commons/context"
+	"github.com/openmfp/golang-commons/context/keys"
+	"github.com/openmfp/golang-commons/logger"
+	"github.com/openmfp/golang-commons/sentry/testsentry"
+)
+
+func serveWithMiddleware(t *testing.T, handler http.Handler, opts ...HTTPMiddlewareOption) *httptest.ResponseRecorder {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /accounts/{name}", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/test", nil)
+	reqCtx := openmfpcontext.AddTenantToContext(req.Context(), "tenant")
+	reqCtx = openmfpcontext.AddSpiffeToContext(reqCtx, "spiffe://openmfp.io/test")
+	reqCtx = context.WithValue(reqCtx, keys.RequestIdCtxKey, "rid")
+
+	rec := httptest.NewRecorder()
+	HTTPMiddleware(ctx, opts...)(mux).ServeHTTP(rec, req.WithContext(reqCtx))
+	return rec
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	t.Run("Capture 5xx response with Sentry error", func(t *testing.T) {
+		transport := testsentry.Init(t)
+
+		rec := serveWithMiddleware(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NotNil(t, sentry.GetHubFromContext(r.Context()))
+			SetRequestError(r.Context(), SentryError(errors.New("test error")))
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		events := transport.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "test error", events[0].Message)
+		assert.Equal(t, map[string]string{
+			"method":    http.MethodGet,
+			"route":     "GET /accounts/{name}",
+			"tenantID":  "tenant",
+			"spiffe":    "spiffe://openmfp.io/test",
+			"requestID": "rid",
+			"status":    "500",
+		}, events[0].Tags)
+	})
+
+	t.Run("Ignore errors which are no Sentry errors or no 5xx", func(t *testing.T) {
+		transport := testsentry.Init(t)
+
+		serveWithMiddleware(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			SetRequestError(r.Context(), errors.New("test error"))
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		serveWithMiddleware(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			SetRequestError(r.Context(), SentryError(errors.New("test error")))
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+
+		assert.Empty(t, transport.Events())
+	})
+
+	t.Run("Recover panic", func(t *testing.T) {
+		transport := testsentry.Init(t)
+
+		rec := serveWithMiddleware(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("oh nose")
+		}))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		events := transport.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "oh nose", events[0].Message)
+		assert.Equal(t, "GET /accounts/{name}", events[0].Tags["route"])
+	})
+
+	t.Run("Repanic", func(t *testing.T) {
+		testsentry.Init(t)
+
+		assert.Panics(t, func() {
+			serveWithMiddleware(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic("oh nose")
+			}), WithRepanic())
+		})
+	})
+}
+
+func TestHTTPMiddlewareWithRequestLogging(t *testing.T) {
+	transport := testsentry.Init(t)
+	cfg := logger.DefaultConfig()
+	cfg.Output = &bytes.Buffer{}
+	cfg.Level = "debug"
+	cfg.Breadcrumbs = true
+	log, err := logger.New(cfg)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	handler := logger.RequestLoggingMiddleware(log)(HTTPMiddleware(ctx)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestLog := logger.LoadLoggerFromContext(r.Context())
+		requestLog.Debug().Msg("loading account")
+		requestLog.Info().Msg("account not ready")
+		SetRequestError(r.Context(), SentryError(errors.New("test error")))
+		w.WriteHeader(http.StatusInternalServerError)
+	})))
+	req := httptest.NewRequest(http.MethodGet, "/accounts/test", nil)
+	req.Header.Set(logger.RequestIdHeader, "rid")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	event := transport.LastEvent()
+	require.NotNil(t, event)
+	assert.Equal(t, "rid", event.Tags["requestID"])
+	messages := []string{}
+	for _, breadcrumb := range event.Breadcrumbs {
+		messages = append(messages, breadcrumb.Message)
+	}
+	assert.Equal(t, []string{"loading account", "account not ready"}, messages)
+}
+
+func TestSetRequestErrorWithoutMiddleware(t *testing.T) {
+	assert.NotPanics(t, func() {
+		SetRequestError(context.Background(), errors.New("test error"))
+	})
+}
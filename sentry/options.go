@@ -0,0 +1,47 @@
+package sentry
+
+import "github.com/getsentry/sentry-go"
+
+// ClientOptions contains the settings of the Sentry client which can be tuned per service
+type ClientOptions struct {
+	// SampleRate is the share of error events sent, between 0.0 and 1.0
+	SampleRate float64
+	// TracesSampleRate is the share of transactions sent, between 0.0 and 1.0
+	TracesSampleRate float64
+	AttachStacktrace bool
+	Debug            bool
+	ServerName       string
+	// IgnoreErrors contains regular expressions matching messages of errors which are not sent
+	IgnoreErrors []string
+	// MaxBreadcrumbs is the maximum number of breadcrumbs kept, the Sentry default is used if zero
+	MaxBreadcrumbs int
+	HTTPProxy      string
+}
+
+// DefaultClientOptions returns the client options used by Start if no others are provided
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		SampleRate:       1.0,
+		TracesSampleRate: 1.0,
+		AttachStacktrace: true,
+	}
+}
+
+// WithClientOptions overwrites the DefaultClientOptions
+func WithClientOptions(clientOptions ClientOptions) Option {
+	return func(o *options) {
+		o.clientOptions = clientOptions
+	}
+}
+
+func (c ClientOptions) apply(clientOptions *sentry.ClientOptions) {
+	clientOptions.SampleRate = c.SampleRate
+	clientOptions.TracesSampleRate = c.TracesSampleRate
+	clientOptions.AttachStacktrace = c.AttachStacktrace
+	clientOptions.Debug = c.Debug
+	clientOptions.ServerName = c.ServerName
+	clientOptions.IgnoreErrors = c.IgnoreErrors
+	clientOptions.MaxBreadcrumbs = c.MaxBreadcrumbs
+	clientOptions.HTTPProxy = c.HTTPProxy
+	clientOptions.HTTPSProxy = c.HTTPProxy
+}
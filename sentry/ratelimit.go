@@ -0,0 +1,139 @@
+package sentry
+
+import (
+	"container/list"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultRateLimitWindow is the default window in which events with the same fingerprint are counted
+	DefaultRateLimitWindow = time.Minute
+	// DefaultRateLimitBurst is the default number of events with the same fingerprint sent per window
+	DefaultRateLimitBurst = 10
+
+	// maxRateLimitEntries limits the number of tracked fingerprints, the ones with the oldest window are dropped first
+	maxRateLimitEntries = 1000
+)
+
+// Stats contains counters of the events passed to CaptureError
+type Stats struct {
+	// Captured is the number of events sent to Sentry
+	Captured uint64
+	// Suppressed is the number of events dropped by the rate limiter
+	Suppressed uint64
+}
+
+var (
+	capturedEvents   atomic.Uint64
+	suppressedEvents atomic.Uint64
+)
+
+// GetStats returns the counters of captured and suppressed events
+func GetStats() Stats {
+	return Stats{
+		Captured:   capturedEvents.Load(),
+		Suppressed: suppressedEvents.Load(),
+	}
+}
+
+// rateLimiter allows a number of events per fingerprint within a fixed window
+type rateLimiter struct {
+	mu      sync.Mutex
+	window  time.Duration
+	burst   int
+	entries map[string]*list.Element
+	// order contains the entries ordered by the start of their window, the oldest at the back
+	order *list.List
+	now   func() time.Time
+}
+
+type rateLimitEntry struct {
+	key   string
+	start time.Time
+	count int
+}
+
+func newRateLimiter(window time.Duration, burst int) *rateLimiter {
+	if window <= 0 || burst <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		window:  window,
+		burst:   burst,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// allow returns false if the burst for the fingerprint is exhausted in the current window
+func (l *rateLimiter) allow(fingerprint []string) bool {
+	if l == nil {
+		return true
+	}
+	key := strings.Join(fingerprint, "\x00")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.removeExpired(now)
+
+	if element, ok := l.entries[key]; ok {
+		entry := element.Value.(*rateLimitEntry)
+		if entry.count >= l.burst {
+			return false
+		}
+		entry.count++
+		return true
+	}
+
+	// the entry with the oldest window is dropped to bound the memory during an error storm
+	if len(l.entries) >= maxRateLimitEntries {
+		l.remove(l.order.Back())
+	}
+	l.entries[key] = l.order.PushFront(&rateLimitEntry{key: key, start: now, count: 1})
+	return true
+}
+
+// removeExpired removes the entries whose window ended, they are all at the back of the order
+func (l *rateLimiter) removeExpired(now time.Time) {
+	for back := l.order.Back(); back != nil && now.Sub(back.Value.(*rateLimitEntry).start) >= l.window; back = l.order.Back() {
+		l.remove(back)
+	}
+}
+
+func (l *rateLimiter) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.entries, element.Value.(*rateLimitEntry).key)
+}
+
+var (
+	quotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	uuidPattern   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	numberPattern = regexp.MustCompile(`[0-9]+`)
+)
+
+// normalizeMessage replaces quoted strings, UUIDs and numbers, so messages which only differ in object names or IDs
+// are rate limited together
+func normalizeMessage(message string) string {
+	message = quotedPattern.ReplaceAllString(message, `"*"`)
+	message = uuidPattern.ReplaceAllString(message, "*")
+	return numberPattern.ReplaceAllString(message, "0")
+}
+
+// fingerprint groups events by the type chain of the error and the values of the selected tags
+func fingerprint(typeChain []string, tags Tags, fingerprintTags []string) []string {
+	result := make([]string, 0, len(typeChain)+len(fingerprintTags))
+	result = append(result, typeChain...)
+	for _, tag := range fingerprintTags {
+		if value, ok := tags[tag]; ok {
+			result = append(result, tag+"="+value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openmfp/golang-commons/sentry/testsentry"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(time.Minute, 2)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.allow([]string{"a"}))
+	assert.True(t, limiter.allow([]string{"a"}))
+	assert.False(t, limiter.allow([]string{"a"}))
+	assert.True(t, limiter.allow([]string{"b"}))
+
+	now = now.Add(time.Minute)
+	assert.True(t, limiter.allow([]string{"a"}))
+}
+
+func TestRateLimiterRemovesExpiredEntries(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(time.Minute, 1)
+	limiter.now = func() time.Time { return now }
+	for i := 0; i < maxRateLimitEntries; i++ {
+		limiter.allow([]string{fmt.Sprint(i)})
+	}
+
+	now = now.Add(time.Minute)
+	limiter.allow([]string{"new"})
+
+	assert.Len(t, limiter.entries, 1)
+}
+
+func TestRateLimiterDropsOldestEntries(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(time.Minute, 1)
+	limiter.now = func() time.Time { return now }
+	for i := 0; i < maxRateLimitEntries; i++ {
+		limiter.allow([]string{fmt.Sprint(i)})
+		now = now.Add(time.Millisecond)
+	}
+
+	assert.True(t, limiter.allow([]string{"new"}))
+
+	assert.Len(t, limiter.entries, maxRateLimitEntries)
+	assert.Equal(t, maxRateLimitEntries, limiter.order.Len())
+	assert.NotContains(t, limiter.entries, "0")
+	assert.False(t, limiter.allow([]string{"1"}))
+	assert.False(t, limiter.allow([]string{"new"}))
+}
+
+func TestNormalizeMessage(t *testing.T) {
+	assert.Equal(t, `accounts "*" not found`, normalizeMessage(`accounts "acme" not found`))
+	assert.Equal(t, "request * failed after 0 attempts", normalizeMessage("request 0f8fad5b-d9cb-469f-a165-70867728950e failed after 3 attempts"))
+	assert.Equal(t, "test error", normalizeMessage("test error"))
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := newRateLimiter(0, 0)
+
+	assert.Nil(t, limiter)
+	assert.True(t, limiter.allow([]string{"a"}))
+}
+
+func TestFingerprint(t *testing.T) {
+	result := fingerprint([]string{"*errors.withStack", "*errors.fundamental"}, Tags{"tenantID": "t1", "path": "p"}, []string{"tenantID", "missing"})
+
+	assert.Equal(t, []string{"*errors.withStack", "*errors.fundamental", "tenantID=t1"}, result)
+}
+
+func TestCaptureErrorRateLimit(t *testing.T) {
+	transport := testsentry.Init(t)
+	currentSettings.Store(&settings{
+		fingerprintTags: []string{"tenantID"},
+		limiter:         newRateLimiter(time.Minute, 1),
+	})
+	t.Cleanup(func() { currentSettings.Store(nil) })
+	before := GetStats()
+
+	CaptureError(fmt.Errorf("test error"), Tags{"tenantID": "t1"})
+	CaptureError(fmt.Errorf("test error"), Tags{"tenantID": "t1"})
+	CaptureError(fmt.Errorf("test error"), Tags{"tenantID": "t2"})
+	CaptureError(fmt.Errorf(`account "acme" failed`), Tags{"tenantID": "t1"})
+	CaptureError(fmt.Errorf(`account "other" failed`), Tags{"tenantID": "t1"})
+
+	events := transport.Events()
+	assert.Len(t, events, 3)
+	assert.Equal(t, []string{defaultFingerprint, "*errors.errorString", "tenantID=t1"}, events[0].Fingerprint)
+	assert.Equal(t, before.Captured+3, GetStats().Captured)
+	assert.Equal(t, before.Suppressed+2, GetStats().Suppressed)
+}
+
+func TestStartWithOptions(t *testing.T) {
+	t.Cleanup(func() { currentSettings.Store(nil) })
+
+	err := Start(context.Background(), "", "", "", "", "", WithFingerprintTags("tenantID"), WithRateLimit(0, 0))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tenantID"}, getSettings().fingerprintTags)
+	assert.Nil(t, getSettings().limiter)
+}
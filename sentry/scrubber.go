@@ -0,0 +1,170 @@
+package sentry
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/openmfp/golang-commons/internal/redact"
+	"github.com/openmfp/golang-commons/logger"
+)
+
+const (
+	// FilteredValue replaces values of denied keys and matches of patterns
+	FilteredValue = "[Filtered]"
+	// DefaultMaxValueLength is the default maximum length of string values sent to Sentry
+	DefaultMaxValueLength = 4096
+
+	truncatedSuffix = "...[truncated]"
+	// maxScrubDepth limits the recursion into nested values, deeper values are filtered
+	maxScrubDepth = 10
+)
+
+// DefaultDeniedKeys contains keys whose values are never sent to Sentry. Keys match like logger.DefaultRedactedKeys,
+// e.g. userEmail matches email but secretName does not match secret. These are the keys redacted by the logger and email.
+var DefaultDeniedKeys = append(slices.Clone(logger.DefaultRedactedKeys), "email")
+
+// DefaultPatterns match JWTs, bearer tokens and email addresses, like the redaction patterns of the logger
+var DefaultPatterns = slices.Clone(logger.DefaultRedactionPatterns)
+
+// Scrubber removes personal data and secrets from Sentry events
+type Scrubber struct {
+	deniedKeys     *redact.Keys
+	patterns       []*regexp.Regexp
+	maxValueLength int
+}
+
+// ScrubberOption configures a Scrubber
+type ScrubberOption func(*Scrubber)
+
+// WithDeniedKeys adds keys whose values are filtered
+func WithDeniedKeys(keys ...string) ScrubberOption {
+	return func(s *Scrubber) {
+		s.deniedKeys.Add(keys...)
+	}
+}
+
+// WithPatterns adds patterns whose matches are filtered from string values
+func WithPatterns(patterns ...*regexp.Regexp) ScrubberOption {
+	return func(s *Scrubber) {
+		s.patterns = append(s.patterns, patterns...)
+	}
+}
+
+// WithMaxValueLength sets the maximum length of string values, longer values are truncated. Zero disables the limit.
+func WithMaxValueLength(length int) ScrubberOption {
+	return func(s *Scrubber) {
+		s.maxValueLength = length
+	}
+}
+
+// NewScrubber creates a Scrubber with the DefaultDeniedKeys, DefaultPatterns and DefaultMaxValueLength
+func NewScrubber(opts ...ScrubberOption) *Scrubber {
+	s := &Scrubber{deniedKeys: redact.NewKeys(), maxValueLength: DefaultMaxValueLength}
+	WithDeniedKeys(DefaultDeniedKeys...)(s)
+	WithPatterns(DefaultPatterns...)(s)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// BeforeSend scrubs extras, tags, messages, exception types and values, breadcrumbs and request data of the event.
+// It can be used as sentry.ClientOptions.BeforeSend.
+func (s *Scrubber) BeforeSend(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+	if event == nil {
+		return nil
+	}
+
+	event.Message = s.ScrubString(event.Message)
+	for i := range event.Exception {
+		// the type of a sentry.Error contains the error message
+		event.Exception[i].Type = s.ScrubString(event.Exception[i].Type)
+		event.Exception[i].Value = s.ScrubString(event.Exception[i].Value)
+	}
+	for key, value := range event.Tags {
+		event.Tags[key] = s.scrubString(key, value)
+	}
+	for key, value := range event.Extra {
+		event.Extra[key] = s.scrubValue(key, value, 0)
+	}
+	for _, breadcrumb := range event.Breadcrumbs {
+		breadcrumb.Message = s.ScrubString(breadcrumb.Message)
+		for key, value := range breadcrumb.Data {
+			breadcrumb.Data[key] = s.scrubValue(key, value, 0)
+		}
+	}
+	if event.Request != nil {
+		event.Request.QueryString = s.ScrubString(event.Request.QueryString)
+		event.Request.Data = s.ScrubString(event.Request.Data)
+		event.Request.Cookies = s.scrubString("cookie", event.Request.Cookies)
+		for key, value := range event.Request.Headers {
+			event.Request.Headers[key] = s.scrubString(key, value)
+		}
+	}
+	return event
+}
+
+// ScrubString filters matches of the patterns and truncates the value
+func (s *Scrubber) ScrubString(value string) string {
+	for _, pattern := range s.patterns {
+		value = pattern.ReplaceAllString(value, FilteredValue)
+	}
+	if s.maxValueLength > 0 && len(value) > s.maxValueLength {
+		value = value[:s.maxValueLength] + truncatedSuffix
+	}
+	return value
+}
+
+func (s *Scrubber) scrubString(key string, value string) string {
+	if value != "" && s.isDenied(key) {
+		return FilteredValue
+	}
+	return s.ScrubString(value)
+}
+
+// scrubValue filters values of denied keys and scrubs strings in nested maps and slices, e.g. GraphQL variables
+func (s *Scrubber) scrubValue(key string, value interface{}, depth int) interface{} {
+	if value == nil {
+		return nil
+	}
+	if s.isDenied(key) || depth > maxScrubDepth {
+		return FilteredValue
+	}
+
+	switch v := value.(type) {
+	case string:
+		return s.ScrubString(v)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, nested := range v {
+			result[k] = s.scrubValue(k, nested, depth+1)
+		}
+		return result
+	case map[string]string:
+		result := make(map[string]string, len(v))
+		for k, nested := range v {
+			result[k] = s.scrubString(k, nested)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, nested := range v {
+			result[i] = s.scrubValue("", nested, depth+1)
+		}
+		return result
+	case []string:
+		result := make([]string, len(v))
+		for i, nested := range v {
+			result[i] = s.ScrubString(nested)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+func (s *Scrubber) isDenied(key string) bool {
+	return s.deniedKeys.Match(key)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/getsentry/sentry-go"
@@ -14,21 +15,98 @@ type (
 	Extras map[string]interface{}
 )
 
-const maxErrorDepth = 10
+const (
+	maxErrorDepth  = 10
+	maxExceptions  = 50
+	mechanismType  = "generic"
+	childSourceFmt = "errors[%d]"
+	// defaultFingerprint keeps the default grouping of Sentry and is refined by the type chain and tags
+	defaultFingerprint = "{{ default }}"
+)
 
-// Start initializes Sentry and flushes errors when the provides context is finished
-func Start(ctx context.Context, dsn, env, region, name, tag string) error {
-	err := sentry.Init(sentry.ClientOptions{
-		Dsn:              dsn,
-		Environment:      fmt.Sprintf("%s-%s", env, region),
-		Release:          fmt.Sprintf("%s:%s", name, tag),
-		AttachStacktrace: true,
-		TracesSampleRate: 1.0,
-	})
+// Option configures the Sentry integration
+type Option func(*options)
+
+type options struct {
+	fingerprintTags []string
+	rateLimitWindow time.Duration
+	rateLimitBurst  int
+	scrubber        *Scrubber
+	clientOptions   ClientOptions
+}
+
+// WithFingerprintTags adds the values of the given tags to the fingerprint of events
+func WithFingerprintTags(tags ...string) Option {
+	return func(o *options) {
+		o.fingerprintTags = append(o.fingerprintTags, tags...)
+	}
+}
+
+// WithRateLimit limits the number of events with the same fingerprint and message sent within the window.
+// A window or burst of zero disables rate limiting.
+func WithRateLimit(window time.Duration, burst int) Option {
+	return func(o *options) {
+		o.rateLimitWindow = window
+		o.rateLimitBurst = burst
+	}
+}
+
+// WithScrubber replaces the default Scrubber applied to all events, nil disables scrubbing
+func WithScrubber(scrubber *Scrubber) Option {
+	return func(o *options) {
+		o.scrubber = scrubber
+	}
+}
+
+type settings struct {
+	fingerprintTags []string
+	limiter         *rateLimiter
+}
+
+// currentSettings is set by Start, events are neither rate limited nor refined by tags before
+var currentSettings atomic.Pointer[settings]
+
+func getSettings() *settings {
+	if s := currentSettings.Load(); s != nil {
+		return s
+	}
+	return &settings{}
+}
+
+// Start initializes Sentry and flushes errors when the provides context is finished.
+// By default events with the same fingerprint and message are limited to DefaultRateLimitBurst per DefaultRateLimitWindow
+// and all events are scrubbed by a Scrubber with default settings.
+func Start(ctx context.Context, dsn, env, region, name, tag string, opts ...Option) error {
+	o := &options{
+		rateLimitWindow: DefaultRateLimitWindow,
+		rateLimitBurst:  DefaultRateLimitBurst,
+		scrubber:        NewScrubber(),
+		clientOptions:   DefaultClientOptions(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	clientOptions := sentry.ClientOptions{
+		Dsn:         dsn,
+		Environment: fmt.Sprintf("%s-%s", env, region),
+		Release:     fmt.Sprintf("%s:%s", name, tag),
+	}
+	o.clientOptions.apply(&clientOptions)
+	if o.scrubber != nil {
+		clientOptions.BeforeSend = o.scrubber.BeforeSend
+	}
+
+	err := sentry.Init(clientOptions)
 	if err != nil {
 		return err
 	}
 
+	currentSettings.Store(&settings{
+		fingerprintTags: o.fingerprintTags,
+		limiter:         newRateLimiter(o.rateLimitWindow, o.rateLimitBurst),
+	})
+
 	go func() {
 		<-ctx.Done()
 		sentry.Flush(5 * time.Second)
@@ -39,18 +117,32 @@ func Start(ctx context.Context, dsn, env, region, name, tag string) error {
 
 // CaptureError sends an error to Sentry with provided tags and extras
 func CaptureError(err error, tags Tags, extras ...Extras) {
+	captureError(context.Background(), sentry.CurrentHub(), err, tags, extras...)
+}
+
+// captureError sends an error to Sentry using the scope of the given hub. The event is tagged with the trace and span ID
+// of the span active in the context and the event ID is recorded on the span.
+func captureError(ctx context.Context, hub *sentry.Hub, err error, tags Tags, extras ...Extras) {
 	if err == nil || !ShouldBeProcessed(err) {
 		return
 	}
 
-	sentry.WithScope(func(scope *sentry.Scope) {
+	hub.WithScope(func(scope *sentry.Scope) {
+		eventTags := Tags{}
 		sentryErr, ok := AsSentryError(err)
 		if ok {
 			scope.SetTags(sentryErr.tags)
 			scope.SetExtras(sentryErr.extras)
+			for k, v := range sentryErr.tags {
+				eventTags.Add(k, v)
+			}
 		}
 
+		scope.SetTags(traceTags(ctx))
 		scope.SetTags(tags)
+		for k, v := range tags {
+			eventTags.Add(k, v)
+		}
 		for _, extra := range extras {
 			scope.SetExtras(extra)
 		}
@@ -61,34 +153,10 @@ func CaptureError(err error, tags Tags, extras ...Extras) {
 		e.Message = err.Error()
 		e.Timestamp = time.Now()
 
-		// iterate over all potentially wrapped errors
-		for i := 0; i < maxErrorDepth && err != nil; i++ {
-			// init exception and extract stacktrace
-			se := sentry.Exception{
-				Value:      err.Error(),
-				Stacktrace: sentry.ExtractStacktrace(err),
-			}
-
-			// add the error type name only if it's not *sentry.Error
-			if reflect.TypeOf(err) != reflect.TypeOf(&Error{}) {
-				se.Type = reflect.TypeOf(err).String()
-			} else {
-				// set the error value as type to have better Sentry output
-				se.Type = err.Error()
-			}
-
-			e.Exception = append(e.Exception, se)
-
-			// follow the wrapped error chain
-			switch previous := err.(type) {
-			case interface{ Unwrap() error }:
-				err = previous.Unwrap()
-			case interface{ Cause() error }:
-				err = previous.Cause()
-			default:
-				err = nil
-			}
-		}
+		// iterate over all potentially wrapped errors, aggregated errors are reported as exception group
+		collector := &exceptionCollector{}
+		collector.collect(err, nil, "")
+		e.Exception = collector.result()
 
 		// if the most recent error doesn't come with a stacktrace, add it
 		if e.Exception[0].Stacktrace == nil {
@@ -100,10 +168,86 @@ func CaptureError(err error, tags Tags, extras ...Extras) {
 			e.Exception[i], e.Exception[j] = e.Exception[j], e.Exception[i]
 		}
 
-		sentry.CaptureEvent(e)
+		s := getSettings()
+		e.Fingerprint = append([]string{defaultFingerprint}, fingerprint(collector.types, eventTags, s.fingerprintTags)...)
+		if !s.limiter.allow(append(e.Fingerprint, normalizeMessage(e.Message))) {
+			suppressedEvents.Add(1)
+			return
+		}
+
+		capturedEvents.Add(1)
+		recordEventID(ctx, hub.CaptureEvent(e))
 	})
 }
 
+// exceptionCollector converts an error tree into Sentry exceptions
+type exceptionCollector struct {
+	exceptions []sentry.Exception
+	types      []string
+	group      bool
+}
+
+// collect follows the wrapped error chain. Errors wrapping several errors are marked as exception group and each
+// wrapped error is added as child exception, so its own stack trace is reported.
+func (c *exceptionCollector) collect(err error, parentID *int, source string) {
+	for i := 0; i < maxErrorDepth && err != nil && len(c.exceptions) < maxExceptions; i++ {
+		id := len(c.exceptions)
+		c.exceptions = append(c.exceptions, newException(err, &sentry.Mechanism{
+			Type:        mechanismType,
+			Source:      source,
+			ExceptionID: id,
+			ParentID:    parentID,
+		}))
+		c.types = append(c.types, reflect.TypeOf(err).String())
+		parentID, source = &id, ""
+
+		// follow the wrapped error chain
+		switch previous := err.(type) {
+		case interface{ Unwrap() []error }:
+			c.group = true
+			c.exceptions[id].Mechanism.IsExceptionGroup = true
+			for j, child := range previous.Unwrap() {
+				c.collect(child, &id, fmt.Sprintf(childSourceFmt, j))
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = previous.Unwrap()
+		case interface{ Cause() error }:
+			err = previous.Cause()
+		default:
+			err = nil
+		}
+	}
+}
+
+// result returns the collected exceptions, mechanisms are only kept to build the tree of an exception group
+func (c *exceptionCollector) result() []sentry.Exception {
+	if !c.group {
+		for i := range c.exceptions {
+			c.exceptions[i].Mechanism = nil
+		}
+	}
+	return c.exceptions
+}
+
+func newException(err error, mechanism *sentry.Mechanism) sentry.Exception {
+	// init exception and extract stacktrace
+	se := sentry.Exception{
+		Value:      err.Error(),
+		Stacktrace: sentry.ExtractStacktrace(err),
+		Mechanism:  mechanism,
+	}
+
+	// add the error type name only if it's not *sentry.Error
+	if reflect.TypeOf(err) != reflect.TypeOf(&Error{}) {
+		se.Type = reflect.TypeOf(err).String()
+	} else {
+		// set the error value as type to have better Sentry output
+		se.Type = err.Error()
+	}
+	return se
+}
+
 // CaptureSentryError is a small wrapper that only captures Sentry errors
 func CaptureSentryError(err error, tags Tags, extras ...Extras) {
 	if IsSentryError(err) {
@@ -6,9 +6,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/openmfp/golang-commons/errors"
+	"github.com/openmfp/golang-commons/sentry/testsentry"
 )
 
 func TestStart(t *testing.T) {
+	t.Cleanup(func() { currentSettings.Store(nil) })
 	err := Start(context.Background(), "", "", "", "", "")
 	assert.NoError(t, err)
 }
@@ -33,3 +37,53 @@ func TestCaptureSentryError(t *testing.T) {
 		CaptureSentryError(err, nil)
 	})
 }
+
+func TestCaptureErrorChain(t *testing.T) {
+	transport := testsentry.Init(t)
+
+	CaptureError(fmt.Errorf("outer: %w", errors.New("inner")), nil)
+
+	events := transport.Events()
+	assert.Len(t, events, 1)
+	exceptions := events[0].Exception
+	assert.Len(t, exceptions, 2)
+	assert.Equal(t, "inner", exceptions[0].Value)
+	assert.Equal(t, "outer: inner", exceptions[1].Value)
+	assert.NotNil(t, exceptions[1].Stacktrace)
+	assert.Nil(t, exceptions[1].Mechanism)
+}
+
+func TestCaptureErrorMultiError(t *testing.T) {
+	transport := testsentry.Init(t)
+	first := errors.New("first")
+	second := fmt.Errorf("second: %w", errors.New("cause"))
+
+	CaptureError(SentryError(errors.Join(first, second)), Tags{"key": "value"})
+
+	events := transport.Events()
+	assert.Len(t, events, 1)
+	assert.Equal(t, "value", events[0].Tags["key"])
+
+	// reversed so the exception group is last
+	exceptions := events[0].Exception
+	assert.Len(t, exceptions, 5)
+	assert.Equal(t, 0, exceptions[4].Mechanism.ExceptionID)
+	assert.NotNil(t, exceptions[4].Stacktrace)
+	group := exceptions[3]
+	assert.Equal(t, "*errors.MultiError", group.Type)
+	assert.True(t, group.Mechanism.IsExceptionGroup)
+	assert.Equal(t, 1, group.Mechanism.ExceptionID)
+	assert.Equal(t, 0, *group.Mechanism.ParentID)
+
+	assert.Equal(t, "first", exceptions[2].Value)
+	assert.Equal(t, "errors[0]", exceptions[2].Mechanism.Source)
+	assert.Equal(t, 1, *exceptions[2].Mechanism.ParentID)
+	assert.NotNil(t, exceptions[2].Stacktrace)
+
+	assert.Equal(t, "second: cause", exceptions[1].Value)
+	assert.Equal(t, "errors[1]", exceptions[1].Mechanism.Source)
+	assert.Equal(t, 1, *exceptions[1].Mechanism.ParentID)
+
+	assert.Equal(t, "cause", exceptions[0].Value)
+	assert.Equal(t, 3, *exceptions[0].Mechanism.ParentID)
+}
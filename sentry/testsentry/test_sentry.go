@@ -0,0 +1,150 @@
+package testsentry
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Transport is an in-memory sentry.Transport collecting all sent events for use in tests
+type Transport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+// Init initializes Sentry with a new in-memory Transport and restores the previous client when the test finished.
+// The configure functions can adjust the client options, e.g. to add a BeforeSend hook.
+func Init(t testing.TB, configure ...func(*sentry.ClientOptions)) *Transport {
+	t.Helper()
+	transport := &Transport{}
+	options := sentry.ClientOptions{Transport: transport}
+	for _, fn := range configure {
+		fn(&options)
+	}
+
+	hub := sentry.CurrentHub()
+	previousClient := hub.Client()
+	client, err := sentry.NewClient(options)
+	if err != nil {
+		t.Fatalf("failed to create sentry client: %v", err)
+	}
+	hub.BindClient(client)
+	t.Cleanup(func() { hub.BindClient(previousClient) })
+
+	return transport
+}
+
+func (t *Transport) Configure(_ sentry.ClientOptions) {}
+
+func (t *Transport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *Transport) Flush(_ time.Duration) bool {
+	return true
+}
+
+func (t *Transport) FlushWithContext(_ context.Context) bool {
+	return true
+}
+
+func (t *Transport) Close() {}
+
+// Events returns all captured events
+func (t *Transport) Events() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]*sentry.Event, len(t.events))
+	copy(result, t.events)
+	return result
+}
+
+// LastEvent returns the most recent captured event or nil if no event was captured
+func (t *Transport) LastEvent() *sentry.Event {
+	events := t.Events()
+	if len(events) == 0 {
+		return nil
+	}
+	return events[len(events)-1]
+}
+
+// Exceptions returns the exceptions of all captured events
+func (t *Transport) Exceptions() []sentry.Exception {
+	result := []sentry.Exception{}
+	for _, event := range t.Events() {
+		result = append(result, event.Exception...)
+	}
+	return result
+}
+
+// Tags returns the tags of the most recent captured event
+func (t *Transport) Tags() map[string]string {
+	event := t.LastEvent()
+	if event == nil {
+		return nil
+	}
+	return event.Tags
+}
+
+// Extras returns the extras of the most recent captured event
+func (t *Transport) Extras() map[string]interface{} {
+	event := t.LastEvent()
+	if event == nil {
+		return nil
+	}
+	return event.Extra
+}
+
+// Reset removes all captured events
+func (t *Transport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = nil
+}
+
+// AssertEventCount asserts the number of captured events
+func (t *Transport) AssertEventCount(tb assert.TestingT, count int) bool {
+	return assert.Len(tb, t.Events(), count, "unexpected number of captured sentry events")
+}
+
+// AssertNoEvents asserts that no event was captured
+func (t *Transport) AssertNoEvents(tb assert.TestingT) bool {
+	return assert.Empty(tb, t.Events(), "expected no captured sentry events")
+}
+
+// AssertException asserts that the most recent captured event contains an exception with the given value
+func (t *Transport) AssertException(tb assert.TestingT, value string) bool {
+	event := t.LastEvent()
+	if event == nil {
+		return assert.Fail(tb, "no sentry event captured")
+	}
+	values := make([]string, 0, len(event.Exception))
+	for _, exception := range event.Exception {
+		values = append(values, exception.Value)
+	}
+	return assert.Contains(tb, values, value, "exception not found in captured sentry event")
+}
+
+// AssertTag asserts that the most recent captured event has the tag with the given value
+func (t *Transport) AssertTag(tb assert.TestingT, key, value string) bool {
+	event := t.LastEvent()
+	if event == nil {
+		return assert.Fail(tb, "no sentry event captured")
+	}
+	return assert.Equal(tb, value, event.Tags[key], "unexpected value of sentry tag %q", key)
+}
+
+// AssertExtra asserts that the most recent captured event has the extra with the given value
+func (t *Transport) AssertExtra(tb assert.TestingT, key string, value interface{}) bool {
+	event := t.LastEvent()
+	if event == nil {
+		return assert.Fail(tb, "no sentry event captured")
+	}
+	return assert.Equal(tb, value, event.Extra[key], "unexpected value of sentry extra %q", key)
+}
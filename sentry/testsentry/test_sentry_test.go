@@ -0,0 +1,87 @@
+package testsentry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingT struct {
+	failed bool
+}
+
+func (r *recordingT) Errorf(_ string, _ ...interface{}) {
+	r.failed = true
+}
+
+func TestTransport(t *testing.T) {
+	t.Run("No events", func(t *testing.T) {
+		// Arrange
+		transport := Init(t)
+		rt := &recordingT{}
+
+		// Assert
+		assert.True(t, transport.AssertNoEvents(t))
+		assert.Nil(t, transport.LastEvent())
+		assert.Nil(t, transport.Tags())
+		assert.Nil(t, transport.Extras())
+		assert.Empty(t, transport.Exceptions())
+		assert.False(t, transport.AssertTag(rt, "key", "value"))
+		assert.True(t, rt.failed)
+	})
+
+	t.Run("Captured events", func(t *testing.T) {
+		// Arrange
+		transport := Init(t)
+
+		// Act
+		sentry.WithScope(func(scope *sentry.Scope) {
+			scope.SetTag("key", "value")
+			scope.SetExtra("extra", 1)
+			sentry.CaptureException(fmt.Errorf("outer: %w", errors.New("inner")))
+		})
+
+		// Assert
+		transport.AssertEventCount(t, 1)
+		transport.AssertException(t, "outer: inner")
+		transport.AssertTag(t, "key", "value")
+		transport.AssertExtra(t, "extra", 1)
+		assert.Len(t, transport.Exceptions(), 2)
+
+		rt := &recordingT{}
+		assert.False(t, transport.AssertException(rt, "unknown"))
+		assert.True(t, rt.failed)
+
+		transport.Reset()
+		transport.AssertNoEvents(t)
+	})
+
+	t.Run("Configure client options", func(t *testing.T) {
+		// Arrange
+		transport := Init(t, func(options *sentry.ClientOptions) {
+			options.BeforeSend = func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+				return nil
+			}
+		})
+
+		// Act
+		sentry.CaptureMessage("dropped")
+
+		// Assert
+		transport.AssertNoEvents(t)
+	})
+}
+
+func TestInitRestoresPreviousClient(t *testing.T) {
+	previousClient := sentry.CurrentHub().Client()
+
+	t.Run("Init", func(t *testing.T) {
+		Init(t)
+		assert.NotEqual(t, previousClient, sentry.CurrentHub().Client())
+	})
+
+	assert.Equal(t, previousClient, sentry.CurrentHub().Client())
+}
@@ -0,0 +1,44 @@
+package sentry
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceIDTag contains the OpenTelemetry trace ID of the span active when the event was captured
+	TraceIDTag = "trace_id"
+	// SpanIDTag contains the OpenTelemetry span ID of the span active when the event was captured
+	SpanIDTag = "span_id"
+	// SpanEventName is the name of the span event recording the ID of a captured Sentry event
+	SpanEventName = "sentry.event"
+	// EventIDAttribute is the attribute of the span event containing the Sentry event ID
+	EventIDAttribute = "sentry.event_id"
+)
+
+// traceTags returns the trace and span ID of the active span, nil if there is none
+func traceTags(ctx context.Context) Tags {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return Tags{
+		TraceIDTag: spanContext.TraceID().String(),
+		SpanIDTag:  spanContext.SpanID().String(),
+	}
+}
+
+// recordEventID adds the ID of the captured event to the active span, so traces link to Sentry
+func recordEventID(ctx context.Context, eventID *sentry.EventID) {
+	if eventID == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent(SpanEventName, trace.WithAttributes(attribute.String(EventIDAttribute, string(*eventID))))
+}
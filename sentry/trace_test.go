@@ -0,0 +1,47 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/openmfp/golang-commons/sentry/testsentry"
+)
+
+func TestCaptureErrorWithActiveSpan(t *testing.T) {
+	transport := testsentry.Init(t)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+
+	CaptureErrorWithContext(ctx, errors.New("test error"), nil)
+	span.End()
+
+	transport.AssertEventCount(t, 1)
+	transport.AssertTag(t, TraceIDTag, span.SpanContext().TraceID().String())
+	transport.AssertTag(t, SpanIDTag, span.SpanContext().SpanID().String())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Len(t, spans[0].Events(), 1)
+	event := spans[0].Events()[0]
+	assert.Equal(t, SpanEventName, event.Name)
+	require.Len(t, event.Attributes, 1)
+	assert.Equal(t, EventIDAttribute, string(event.Attributes[0].Key))
+	assert.Equal(t, string(transport.LastEvent().EventID), event.Attributes[0].Value.AsString())
+}
+
+func TestCaptureErrorWithoutSpan(t *testing.T) {
+	transport := testsentry.Init(t)
+
+	CaptureErrorWithContext(context.Background(), errors.New("test error"), nil)
+
+	transport.AssertEventCount(t, 1)
+	assert.NotContains(t, transport.Tags(), TraceIDTag)
+	assert.NotContains(t, transport.Tags(), SpanIDTag)
+}